@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/caarlos0/env/v9"
 )
@@ -9,11 +10,15 @@ import (
 type (
 	// Config -.
 	Config struct {
-		App     App
-		HTTP    HTTP
-		Log     Log
-		PG      PG
-		Metrics Metrics
+		App         App
+		HTTP        HTTP
+		Log         Log
+		PG          PG
+		Metrics     Metrics
+		Assignment  Assignment
+		CodeOwners  CodeOwners
+		StaleReview StaleReview
+		Access      Access
 	}
 
 	// App -.
@@ -48,6 +53,41 @@ type (
 	Metrics struct {
 		Enabled bool `env:"METRICS_ENABLED" envDefault:"true"`
 	}
+
+	// Assignment -.
+	Assignment struct {
+		Strategy      string `env:"ASSIGNMENT_STRATEGY" envDefault:"RANDOM"`
+		Deterministic bool   `env:"ASSIGNMENT_DETERMINISTIC" envDefault:"false"`
+		// ReassignOnDeactivate default for reassign_reviews flag of /users/setIsActive
+		ReassignOnDeactivate bool `env:"ASSIGNMENT_REASSIGN_ON_DEACTIVATE" envDefault:"false"`
+		// MaxReassignments per pull request, 0 means unlimited
+		MaxReassignments int `env:"ASSIGNMENT_MAX_REASSIGNMENTS" envDefault:"5"`
+		// HierarchyEscalation take siblings and parent teams when a team has too few candidates
+		HierarchyEscalation bool `env:"ASSIGNMENT_HIERARCHY_ESCALATION" envDefault:"false"`
+	}
+
+	// CodeOwners -.
+	CodeOwners struct {
+		File string `env:"CODEOWNERS_FILE"`
+	}
+
+	// StaleReview -.
+	StaleReview struct {
+		Enabled bool `env:"STALE_REVIEW_ENABLED" envDefault:"false"`
+		// SLA time a reviewer has to act before the review is stale
+		SLA      time.Duration `env:"STALE_REVIEW_SLA" envDefault:"48h"`
+		Interval time.Duration `env:"STALE_REVIEW_INTERVAL" envDefault:"10m"`
+		// DryRun only logs stale reviews and actions that would be applied
+		DryRun bool `env:"STALE_REVIEW_DRY_RUN" envDefault:"false"`
+	}
+
+	// Access -.
+	Access struct {
+		// AdminUserIDs users allowed to override team rules in any team, e.g. force a merge
+		AdminUserIDs []string `env:"ADMIN_USER_IDS" envSeparator:","`
+		// RestrictReviewerEdits allow manual reviewer changes only to admins, maintainers and the lead of the PR team
+		RestrictReviewerEdits bool `env:"ACCESS_RESTRICT_REVIEWER_EDITS" envDefault:"false"`
+	}
 )
 
 // NewConfig returns app config.
@@ -57,5 +97,22 @@ func NewConfig() (*Config, error) {
 		return nil, fmt.Errorf("config error: %w", err)
 	}
 
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("config error: %w", err)
+	}
+
 	return cfg, nil
 }
+
+func (c *Config) validate() error {
+	if !c.StaleReview.Enabled {
+		return nil
+	}
+	if c.StaleReview.SLA <= 0 {
+		return fmt.Errorf("STALE_REVIEW_SLA must be positive, got %s", c.StaleReview.SLA)
+	}
+	if c.StaleReview.Interval <= 0 {
+		return fmt.Errorf("STALE_REVIEW_INTERVAL must be positive, got %s", c.StaleReview.Interval)
+	}
+	return nil
+}
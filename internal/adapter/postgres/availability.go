@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Egorrrad/avitotechBackendPR/internal/domain"
+	"github.com/Egorrrad/avitotechBackendPR/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// availableNow filters out users (aliased as u) with an unavailability period covering current time.
+var availableNow = squirrel.Expr(`NOT EXISTS (SELECT 1 FROM user_unavailability ua
+	WHERE ua.user_id = u.id AND ua.starts_at <= NOW() AND ua.ends_at > NOW())`)
+
+type UnavailabilityRepo struct {
+	*postgres.Postgres
+}
+
+func NewUnavailabilityRepo(pg *postgres.Postgres) *UnavailabilityRepo {
+	return &UnavailabilityRepo{pg}
+}
+
+func (r *UnavailabilityRepo) Create(ctx context.Context, period *domain.Unavailability) error {
+	q := r.GetQueryer(ctx)
+
+	sql := `INSERT INTO user_unavailability (user_id, starts_at, ends_at, reason)
+		SELECT id, $2, $3, $4 FROM users WHERE user_id = $1
+		RETURNING id`
+
+	err := q.QueryRow(ctx, sql, period.UserID, period.StartsAt, period.EndsAt, period.Reason).Scan(&period.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrUserNotFound
+	}
+	return err
+}
+
+func (r *UnavailabilityRepo) selectPeriods() squirrel.SelectBuilder {
+	return r.Builder.
+		Select("ua.id", "u.user_id", "ua.starts_at", "ua.ends_at", "ua.reason").
+		From("user_unavailability ua").
+		Join("users u ON ua.user_id = u.id")
+}
+
+func (r *UnavailabilityRepo) GetByID(ctx context.Context, id int64) (*domain.Unavailability, error) {
+	q := r.GetQueryer(ctx)
+
+	sql, args, err := r.selectPeriods().Where(squirrel.Eq{"ua.id": id}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var p domain.Unavailability
+	err = q.QueryRow(ctx, sql, args...).Scan(&p.ID, &p.UserID, &p.StartsAt, &p.EndsAt, &p.Reason)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+func (r *UnavailabilityRepo) GetByUserID(ctx context.Context, userID string) ([]domain.Unavailability, error) {
+	q := r.GetQueryer(ctx)
+
+	sql, args, err := r.selectPeriods().
+		Where(squirrel.Eq{"u.user_id": userID}).
+		OrderBy("ua.starts_at").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	periods := make([]domain.Unavailability, 0)
+	for rows.Next() {
+		var p domain.Unavailability
+		if err := rows.Scan(&p.ID, &p.UserID, &p.StartsAt, &p.EndsAt, &p.Reason); err != nil {
+			return nil, err
+		}
+		periods = append(periods, p)
+	}
+
+	return periods, rows.Err()
+}
+
+func (r *UnavailabilityRepo) Update(ctx context.Context, period *domain.Unavailability) error {
+	q := r.GetQueryer(ctx)
+
+	sql, args, err := r.Builder.
+		Update("user_unavailability").
+		Set("starts_at", period.StartsAt).
+		Set("ends_at", period.EndsAt).
+		Set("reason", period.Reason).
+		Where(squirrel.Eq{"id": period.ID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	tag, err := q.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrUnavailabilityNotFound
+	}
+	return nil
+}
+
+func (r *UnavailabilityRepo) Delete(ctx context.Context, id int64) error {
+	q := r.GetQueryer(ctx)
+
+	sql, args, err := r.Builder.Delete("user_unavailability").Where(squirrel.Eq{"id": id}).ToSql()
+	if err != nil {
+		return err
+	}
+
+	tag, err := q.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrUnavailabilityNotFound
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/Egorrrad/avitotechBackendPR/internal/domain"
+	"github.com/Egorrrad/avitotechBackendPR/pkg/postgres"
+)
+
+type CodeOwnerRepo struct {
+	*postgres.Postgres
+}
+
+func NewCodeOwnerRepo(pg *postgres.Postgres) *CodeOwnerRepo {
+	return &CodeOwnerRepo{pg}
+}
+
+func (r *CodeOwnerRepo) List(ctx context.Context) ([]domain.CodeOwnerRule, error) {
+	q := r.GetQueryer(ctx)
+
+	sql, args, err := r.Builder.
+		Select("pattern", "owner_users", "owner_teams").
+		From("code_owner_rules").
+		OrderBy("position").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := make([]domain.CodeOwnerRule, 0)
+	for rows.Next() {
+		var rule domain.CodeOwnerRule
+		if err := rows.Scan(&rule.Pattern, &rule.Users, &rule.Teams); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, rows.Err()
+}
+
+// Replace atomically swaps the whole rule set, rule order is preserved.
+func (r *CodeOwnerRepo) Replace(ctx context.Context, rules []domain.CodeOwnerRule) error {
+	return r.RunInTx(ctx, func(ctx context.Context) error {
+		q := r.GetQueryer(ctx)
+
+		sql, args, err := r.Builder.Delete("code_owner_rules").ToSql()
+		if err != nil {
+			return err
+		}
+		if _, err = q.Exec(ctx, sql, args...); err != nil {
+			return err
+		}
+
+		if len(rules) == 0 {
+			return nil
+		}
+
+		insert := r.Builder.Insert("code_owner_rules").Columns("position", "pattern", "owner_users", "owner_teams")
+		for i, rule := range rules {
+			insert = insert.Values(i, rule.Pattern, rule.Users, rule.Teams)
+		}
+
+		sql, args, err = insert.ToSql()
+		if err != nil {
+			return err
+		}
+
+		_, err = q.Exec(ctx, sql, args...)
+		return err
+	})
+}
@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/Egorrrad/avitotechBackendPR/internal/domain"
+	"github.com/Masterminds/squirrel"
+)
+
+// AppendEvents adds events to pull request history.
+func (r *PullRequestRepo) AppendEvents(ctx context.Context, prID string, events ...domain.PREvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	q := r.GetQueryer(ctx)
+
+	prInternalID, err := r.getPRInternalID(ctx, prID)
+	if err != nil {
+		return err
+	}
+
+	insert := r.Builder.
+		Insert("pr_events").
+		Columns("pr_id", "event_type", "actor", "reviewer_id", "replaced_by", "from_status", "to_status", "details",
+			"seed")
+	for _, e := range events {
+		insert = insert.Values(prInternalID, string(e.Type), e.Actor, e.ReviewerID, e.ReplacedBy,
+			string(e.FromStatus), string(e.ToStatus), e.Details, e.Seed)
+	}
+
+	sql, args, err := insert.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = q.Exec(ctx, sql, args...)
+	return err
+}
+
+// GetEvents returns pull request history in the order events were recorded.
+func (r *PullRequestRepo) GetEvents(ctx context.Context, prID string) ([]domain.PREvent, error) {
+	q := r.GetQueryer(ctx)
+
+	sql, args, err := r.Builder.
+		Select("e.event_type", "e.actor", "e.reviewer_id", "e.replaced_by",
+			"e.from_status", "e.to_status", "e.details", "e.created_at", "e.seed").
+		From("pr_events e").
+		Join("pull_requests pr ON pr.id = e.pr_id").
+		Where(squirrel.Eq{"pr.pull_request_id": prID}).
+		OrderBy("e.id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]domain.PREvent, 0)
+	for rows.Next() {
+		var e domain.PREvent
+		err := rows.Scan(&e.Type, &e.Actor, &e.ReviewerID, &e.ReplacedBy,
+			&e.FromStatus, &e.ToStatus, &e.Details, &e.CreatedAt, &e.Seed)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+
+	return events, rows.Err()
+}
+
+// CountUserEvents returns how many events of the type pull request history has,
+// events recorded on behalf of the system are not counted.
+func (r *PullRequestRepo) CountUserEvents(ctx context.Context, prID string, eventType domain.PREventType) (int, error) {
+	q := r.GetQueryer(ctx)
+
+	sql, args, err := r.Builder.
+		Select("COUNT(*)").
+		From("pr_events e").
+		Join("pull_requests pr ON pr.id = e.pr_id").
+		Where(squirrel.Eq{"pr.pull_request_id": prID, "e.event_type": string(eventType)}).
+		Where(squirrel.NotEq{"e.actor": domain.ActorSystem}).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	err = q.QueryRow(ctx, sql, args...).Scan(&count)
+	return count, err
+}
@@ -13,8 +13,6 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const MaxReviewers = 2
-
 type PullRequestRepo struct {
 	*postgres.Postgres
 
@@ -137,6 +135,12 @@ func (r *PullRequestRepo) mapPullRequestFromRows(rows pgx.Rows) ([]*domain.PullR
 			statusID           int
 			createdAt          time.Time
 			mergedAt           pgtype.Timestamptz
+			paths              []string
+			seed               pgtype.Int8
+			priority           string
+			prLabels           []string
+			teamName           string
+			released           []string
 			reviewerExternalID pgtype.Text
 		)
 
@@ -149,6 +153,12 @@ func (r *PullRequestRepo) mapPullRequestFromRows(rows pgx.Rows) ([]*domain.PullR
 			&statusID,
 			&createdAt,
 			&mergedAt,
+			&paths,
+			&seed,
+			&priority,
+			&prLabels,
+			&teamName,
+			&released,
 			&reviewerExternalID,
 		)
 
@@ -167,15 +177,23 @@ func (r *PullRequestRepo) mapPullRequestFromRows(rows pgx.Rows) ([]*domain.PullR
 				PullRequestID:     pr.PullRequestID,
 				PullRequestName:   pr.PullRequestName,
 				AuthorID:          pr.AuthorID,
+				ChangedPaths:      paths,
+				Priority:          domain.PullRequestPriority(priority),
+				Labels:            prLabels,
+				TeamName:          teamName,
+				ReleasedReviewers: released,
 				Status:            statusName,
 				CreatedAt:         &createdAt,
-				AssignedReviewers: make([]string, 0, MaxReviewers),
+				AssignedReviewers: make([]string, 0, domain.DefaultMaxReviewers),
 			}
 			if mergedAt.Valid {
 				p.MergedAt = &mergedAt.Time
 			} else {
 				p.MergedAt = nil
 			}
+			if seed.Valid {
+				p.AssignmentSeed = &seed.Int64
+			}
 			prMap[prID] = p
 		}
 
@@ -226,8 +244,11 @@ func (r *PullRequestRepo) Create(ctx context.Context, pr *domain.PullRequest) (*
 	var prInternalID int
 	sql, args, err := r.Builder.
 		Insert("pull_requests").
-		Columns("pull_request_id", "pull_request_name", "author_id", "status", "created_at").
-		Values(pr.PullRequestID, pr.PullRequestName, authorInternalID, statusID, time.Now()).
+		Columns("pull_request_id", "pull_request_name", "author_id", "status", "created_at", "changed_paths",
+			"assignment_seed", "priority", "labels", "team_id").
+		Values(pr.PullRequestID, pr.PullRequestName, authorInternalID, statusID, time.Now(), changedPaths(pr),
+			pr.AssignmentSeed, string(pr.Priority), labels(pr.Labels),
+			squirrel.Expr("(SELECT id FROM teams WHERE name = ?)", pr.TeamName)).
 		Suffix("RETURNING id").
 		ToSql()
 
@@ -259,9 +280,34 @@ func (r *PullRequestRepo) Create(ctx context.Context, pr *domain.PullRequest) (*
 		}
 	}
 
+	if err := r.syncReviewerHistory(ctx, prInternalID, pr.AssignedReviewers, nil); err != nil {
+		return nil, err
+	}
+
 	return pr, nil
 }
 
+func changedPaths(pr *domain.PullRequest) []string {
+	if pr.ChangedPaths == nil {
+		return []string{}
+	}
+	return pr.ChangedPaths
+}
+
+func labels(l []string) []string {
+	if l == nil {
+		return []string{}
+	}
+	return l
+}
+
+func releasedReviewers(pr *domain.PullRequest) []string {
+	if pr.ReleasedReviewers == nil {
+		return []string{}
+	}
+	return pr.ReleasedReviewers
+}
+
 func (r *PullRequestRepo) GetByID(ctx context.Context, id string) (*domain.PullRequest, error) {
 	q := r.GetQueryer(ctx)
 
@@ -269,11 +315,12 @@ func (r *PullRequestRepo) GetByID(ctx context.Context, id string) (*domain.PullR
 		Select(
 			"pr.id", "pr.pull_request_id", "pr.pull_request_name",
 			"pr.author_id", "author.user_id",
-			"pr.status", "pr.created_at", "pr.merged_at",
-			"r_user.user_id",
+			"pr.status", "pr.created_at", "pr.merged_at", "pr.changed_paths", "pr.assignment_seed",
+			"pr.priority", "pr.labels", "COALESCE(pr_team.name, '')", "pr.released_reviewers", "r_user.user_id",
 		).
 		From("pull_requests pr").
 		Join("users author ON pr.author_id = author.id").
+		LeftJoin("teams pr_team ON pr.team_id = pr_team.id").
 		LeftJoin("reviewers reviewer ON pr.id = reviewer.pr_id").
 		LeftJoin("users r_user ON reviewer.user_id = r_user.id").
 		Where(squirrel.Eq{"pr.pull_request_id": id}).
@@ -298,7 +345,88 @@ func (r *PullRequestRepo) GetByID(ctx context.Context, id string) (*domain.PullR
 		return nil, nil
 	}
 
-	return prs[0], nil
+	pr := prs[0]
+	if pr.ReviewDecisions, err = r.latestDecisions(ctx, pr); err != nil {
+		return nil, err
+	}
+	if pr.RemovedReviewers, err = r.removedReviewers(ctx, pr); err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+// latestDecisions returns the latest decision of each currently assigned reviewer made since
+// the reviewer's current assignment, in the order of AssignedReviewers.
+func (r *PullRequestRepo) latestDecisions(ctx context.Context, pr *domain.PullRequest) ([]domain.ReviewerDecision, error) {
+	q := r.GetQueryer(ctx)
+
+	sql, args, err := r.Builder.
+		Select("DISTINCT ON (d.user_id) u.user_id", "d.decision", "d.created_at").
+		From("review_decisions d").
+		Join("pull_requests pr ON pr.id = d.pr_id").
+		// decisions made before the reviewer's current assignment belong to an earlier review
+		Join("pr_reviewer_history h ON h.pr_id = d.pr_id AND h.user_id = d.user_id AND h.unassigned_at IS NULL "+
+			"AND d.created_at >= h.assigned_at").
+		Join("users u ON u.id = d.user_id").
+		Where(squirrel.Eq{"pr.pull_request_id": pr.PullRequestID}).
+		OrderBy("d.user_id", "d.created_at DESC", "d.id DESC").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	byUser := make(map[string]domain.ReviewerDecision)
+	for rows.Next() {
+		var d domain.ReviewerDecision
+		if err := rows.Scan(&d.UserID, &d.Decision, &d.CreatedAt); err != nil {
+			return nil, err
+		}
+		byUser[d.UserID] = d
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	decisions := make([]domain.ReviewerDecision, 0, len(byUser))
+	for _, id := range pr.AssignedReviewers {
+		if d, ok := byUser[id]; ok {
+			decisions = append(decisions, d)
+		}
+	}
+	return decisions, nil
+}
+
+func (r *PullRequestRepo) AddReviewDecision(ctx context.Context, prID, userID string, decision domain.ReviewDecision) error {
+	q := r.GetQueryer(ctx)
+
+	prInternalID, err := r.getPRInternalID(ctx, prID)
+	if err != nil {
+		return err
+	}
+
+	users, err := r.resolveExternalUserIDsToInternalIDs(ctx, []string{userID})
+	if err != nil {
+		return err
+	}
+
+	sql, args, err := r.Builder.
+		Insert("review_decisions").
+		Columns("pr_id", "user_id", "decision", "created_at").
+		Values(prInternalID, users[0].ID, string(decision), time.Now()).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = q.Exec(ctx, sql, args...)
+	return err
 }
 
 func (r *PullRequestRepo) Update(ctx context.Context, pr *domain.PullRequest) error {
@@ -311,7 +439,7 @@ func (r *PullRequestRepo) Update(ctx context.Context, pr *domain.PullRequest) er
 		return err
 	}
 
-	return r.updateReviewers(ctx, prInternalID, pr.AssignedReviewers)
+	return r.updateReviewers(ctx, prInternalID, pr.AssignedReviewers, pr.RemovedReviewers)
 }
 
 func (r *PullRequestRepo) getPRInternalID(ctx context.Context, pullRequestID string) (int, error) {
@@ -350,7 +478,9 @@ func (r *PullRequestRepo) updatePRData(ctx context.Context, prInternalID int, pr
 	updateBuilder := r.Builder.
 		Update("pull_requests").
 		Set("pull_request_name", pr.PullRequestName).
-		Set("status", statusID)
+		Set("status", statusID).
+		Set("assignment_seed", pr.AssignmentSeed).
+		Set("released_reviewers", releasedReviewers(pr))
 
 	if pr.MergedAt != nil {
 		updateBuilder = updateBuilder.Set("merged_at", mergedAt)
@@ -368,16 +498,18 @@ func (r *PullRequestRepo) updatePRData(ctx context.Context, prInternalID int, pr
 	return err
 }
 
-func (r *PullRequestRepo) updateReviewers(ctx context.Context, prInternalID int, reviewers []string) error {
+func (r *PullRequestRepo) updateReviewers(ctx context.Context, prInternalID int, reviewers, removed []string) error {
 	if err := r.deleteReviewers(ctx, prInternalID); err != nil {
 		return err
 	}
 
 	if len(reviewers) > 0 {
-		return r.insertReviewers(ctx, prInternalID, reviewers)
+		if err := r.insertReviewers(ctx, prInternalID, reviewers); err != nil {
+			return err
+		}
 	}
 
-	return nil
+	return r.syncReviewerHistory(ctx, prInternalID, reviewers, removed)
 }
 
 func (r *PullRequestRepo) deleteReviewers(ctx context.Context, prInternalID int) error {
@@ -432,6 +564,8 @@ func (r *PullRequestRepo) GetByReviewerID(ctx context.Context, userID string) ([
 			"pr.pull_request_name",
 			"author.user_id",
 			"pr.status",
+			"pr.priority",
+			"pr.labels",
 		).
 		From("reviewers r").
 		Join("pull_requests pr ON r.pr_id = pr.id").
@@ -459,6 +593,8 @@ func (r *PullRequestRepo) GetByReviewerID(ctx context.Context, userID string) ([
 			&pr.PullRequestName,
 			&pr.AuthorID,
 			&statusID,
+			&pr.Priority,
+			&pr.Labels,
 		)
 		if err != nil {
 			return nil, err
@@ -496,3 +632,95 @@ func (r *PullRequestRepo) Exists(ctx context.Context, id string) (bool, error) {
 
 	return count > 0, nil
 }
+
+// Lock locks pull request row until the end of the transaction in ctx,
+// a missing pull request is not an error.
+func (r *PullRequestRepo) Lock(ctx context.Context, id string) error {
+	q := r.GetQueryer(ctx)
+
+	sql, args, err := r.Builder.
+		Select("id").
+		From("pull_requests").
+		Where(squirrel.Eq{"pull_request_id": id}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = q.Exec(ctx, sql, args...)
+	return err
+}
+
+// CountOpenReviews returns number of OPEN pull requests each user reviews.
+// Users without open reviews are present in result with zero count.
+func (r *PullRequestRepo) CountOpenReviews(ctx context.Context, userIDs []string) (map[string]int, error) {
+	if len(userIDs) == 0 {
+		return map[string]int{}, nil
+	}
+
+	q := r.GetQueryer(ctx)
+
+	openStatusID, err := r.toStatusID(domain.PullRequestStatusOPEN)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args, err := r.Builder.
+		Select("u.user_id", "COUNT(pr.id)").
+		From("users u").
+		LeftJoin("reviewers r ON r.user_id = u.id").
+		LeftJoin("pull_requests pr ON pr.id = r.pr_id AND pr.status = ?", openStatusID).
+		Where(squirrel.Eq{"u.user_id": userIDs}).
+		GroupBy("u.user_id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	loads := make(map[string]int, len(userIDs))
+	for rows.Next() {
+		var (
+			userID string
+			count  int
+		)
+		if err := rows.Scan(&userID, &count); err != nil {
+			return nil, err
+		}
+		loads[userID] = count
+	}
+
+	return loads, rows.Err()
+}
+
+// RecordForcedMerge stores audit record of a merge that bypassed the merge policy.
+func (r *PullRequestRepo) RecordForcedMerge(ctx context.Context, prID, actor string, missing []string) error {
+	q := r.GetQueryer(ctx)
+
+	prInternalID, err := r.getPRInternalID(ctx, prID)
+	if err != nil {
+		return err
+	}
+
+	if missing == nil {
+		missing = []string{}
+	}
+
+	sql, args, err := r.Builder.
+		Insert("forced_merges").
+		Columns("pr_id", "actor", "missing", "forced_at").
+		Values(prInternalID, actor, missing, time.Now()).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = q.Exec(ctx, sql, args...)
+	return err
+}
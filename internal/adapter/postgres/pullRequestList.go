@@ -0,0 +1,193 @@
+package postgres
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Egorrrad/avitotechBackendPR/internal/domain"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// cursorTimeLayout keeps full precision of TIMESTAMPTZ columns
+const cursorTimeLayout = "2006-01-02 15:04:05.999999Z07:00"
+
+// listCursor is a keyset position: sort value and internal id of the last row of a page.
+type listCursor struct {
+	SortBy domain.PullRequestSortField `json:"s"`
+	Value  string                      `json:"v"`
+	ID     int                         `json:"id"`
+}
+
+func encodeCursor(c listCursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(s string) (*listCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, domain.ErrInvalidListParams
+	}
+
+	var c listCursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, domain.ErrInvalidListParams
+	}
+	return &c, nil
+}
+
+// List returns a page of pull requests matching params and cursor of the next page.
+// Sort field, direction and limit are expected to be validated by the caller.
+func (r *PullRequestRepo) List(ctx context.Context, params domain.GetPullRequestListParams) ([]*domain.PullRequest, string, error) {
+	q := r.GetQueryer(ctx)
+
+	sortColumn := "pr.created_at"
+	cursorCast := "::timestamptz"
+	if params.SortBy == domain.PullRequestSortName {
+		sortColumn = "pr.pull_request_name"
+		cursorCast = ""
+	}
+
+	direction, cmp := "ASC", ">"
+	if params.Desc {
+		direction, cmp = "DESC", "<"
+	}
+
+	builder := r.Builder.
+		Select(
+			"pr.id", "pr.pull_request_id", "pr.pull_request_name", "author.user_id",
+			"pr.status", "pr.created_at", "pr.merged_at", "pr.changed_paths", "pr.assignment_seed",
+			"pr.priority", "pr.labels", "COALESCE(pr_team.name, '')",
+			`ARRAY(SELECT ru.user_id FROM reviewers rv JOIN users ru ON ru.id = rv.user_id
+				WHERE rv.pr_id = pr.id ORDER BY ru.user_id)`,
+		).
+		From("pull_requests pr").
+		Join("users author ON pr.author_id = author.id").
+		LeftJoin("teams pr_team ON pr.team_id = pr_team.id").
+		OrderBy(sortColumn+" "+direction, "pr.id "+direction).
+		Limit(uint64(params.Limit) + 1)
+
+	if params.Status != "" {
+		statusID, err := r.toStatusID(params.Status)
+		if err != nil {
+			return nil, "", domain.ErrInvalidListParams
+		}
+		builder = builder.Where(squirrel.Eq{"pr.status": statusID})
+	}
+	if params.AuthorID != "" {
+		builder = builder.Where(squirrel.Eq{"author.user_id": params.AuthorID})
+	}
+	if params.ReviewerID != "" {
+		builder = builder.Where(`EXISTS (SELECT 1 FROM reviewers rv JOIN users ru ON ru.id = rv.user_id
+			WHERE rv.pr_id = pr.id AND ru.user_id = ?)`, params.ReviewerID)
+	}
+	if params.TeamName != "" {
+		builder = builder.Where(`EXISTS (SELECT 1 FROM team_member tm JOIN teams t ON t.id = tm.team_id
+			WHERE tm.user_id = pr.author_id AND t.name = ?)`, params.TeamName)
+	}
+	if params.Name != "" {
+		builder = builder.Where("pr.pull_request_name ILIKE ?", "%"+escapeLike(params.Name)+"%")
+	}
+	if params.CreatedFrom != nil {
+		builder = builder.Where(squirrel.GtOrEq{"pr.created_at": *params.CreatedFrom})
+	}
+	if params.CreatedTo != nil {
+		builder = builder.Where(squirrel.Lt{"pr.created_at": *params.CreatedTo})
+	}
+	if params.MergedFrom != nil {
+		builder = builder.Where(squirrel.GtOrEq{"pr.merged_at": *params.MergedFrom})
+	}
+	if params.MergedTo != nil {
+		builder = builder.Where(squirrel.Lt{"pr.merged_at": *params.MergedTo})
+	}
+
+	if params.Cursor != "" {
+		cursor, err := decodeCursor(params.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		if cursor.SortBy != params.SortBy {
+			return nil, "", domain.ErrInvalidListParams
+		}
+		builder = builder.Where(
+			fmt.Sprintf("(%s, pr.id) %s (?%s, ?)", sortColumn, cmp, cursorCast), cursor.Value, cursor.ID)
+	}
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		return nil, "", err
+	}
+
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	var (
+		prs     = make([]*domain.PullRequest, 0, params.Limit)
+		lastID  int
+		hasMore bool
+	)
+	for rows.Next() {
+		// the extra row only tells there is a next page
+		if len(prs) == params.Limit {
+			hasMore = true
+			break
+		}
+
+		var (
+			pr        domain.PullRequest
+			id        int
+			statusID  int
+			createdAt time.Time
+			mergedAt  pgtype.Timestamptz
+			seed      pgtype.Int8
+		)
+
+		err := rows.Scan(&id, &pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID,
+			&statusID, &createdAt, &mergedAt, &pr.ChangedPaths, &seed, &pr.Priority, &pr.Labels,
+			&pr.TeamName, &pr.AssignedReviewers)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if pr.Status, err = r.toStatusName(statusID); err != nil {
+			return nil, "", err
+		}
+		pr.CreatedAt = &createdAt
+		if mergedAt.Valid {
+			pr.MergedAt = &mergedAt.Time
+		}
+		if seed.Valid {
+			pr.AssignmentSeed = &seed.Int64
+		}
+
+		prs = append(prs, &pr)
+		lastID = id
+	}
+	if rows.Err() != nil {
+		return nil, "", rows.Err()
+	}
+
+	var next string
+	if hasMore {
+		last := prs[len(prs)-1]
+		value := last.PullRequestName
+		if params.SortBy != domain.PullRequestSortName {
+			value = last.CreatedAt.UTC().Format(cursorTimeLayout)
+		}
+		next = encodeCursor(listCursor{SortBy: params.SortBy, Value: value, ID: lastID})
+	}
+
+	return prs, next, nil
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
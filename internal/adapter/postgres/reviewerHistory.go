@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/Egorrrad/avitotechBackendPR/internal/domain"
+	"github.com/Masterminds/squirrel"
+)
+
+// syncReviewerHistory records reviewers as currently assigned, marks every other reviewer
+// the pull request had as unassigned and the ones in removed as removed explicitly.
+func (r *PullRequestRepo) syncReviewerHistory(ctx context.Context, prInternalID int, reviewers,
+	removed []string) error {
+	q := r.GetQueryer(ctx)
+
+	reviewerUsers, err := r.resolveExternalUserIDsToInternalIDs(ctx, reviewers)
+	if err != nil {
+		return err
+	}
+
+	assigned := make([]int, 0, len(reviewerUsers))
+	for _, u := range reviewerUsers {
+		assigned = append(assigned, u.ID)
+	}
+
+	if len(assigned) > 0 {
+		insert := r.Builder.Insert("pr_reviewer_history").Columns("pr_id", "user_id")
+		for _, id := range assigned {
+			insert = insert.Values(prInternalID, id)
+		}
+
+		sql, args, err := insert.
+			Suffix(`ON CONFLICT (pr_id, user_id) DO UPDATE SET
+				assigned_at = CASE WHEN pr_reviewer_history.unassigned_at IS NULL
+					THEN pr_reviewer_history.assigned_at ELSE NOW() END,
+				unassigned_at = NULL,
+				removed_at = NULL`).
+			ToSql()
+		if err != nil {
+			return err
+		}
+
+		if _, err = q.Exec(ctx, sql, args...); err != nil {
+			return err
+		}
+	}
+
+	sql, args, err := r.Builder.
+		Update("pr_reviewer_history").
+		Set("unassigned_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"pr_id": prInternalID, "unassigned_at": nil}).
+		Where(squirrel.NotEq{"user_id": assigned}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = q.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+
+	if len(removed) == 0 {
+		return nil
+	}
+
+	removedUsers, err := r.resolveExternalUserIDsToInternalIDs(ctx, removed)
+	if err != nil {
+		return err
+	}
+
+	removedIDs := make([]int, 0, len(removedUsers))
+	for _, u := range removedUsers {
+		removedIDs = append(removedIDs, u.ID)
+	}
+
+	sql, args, err = r.Builder.
+		Update("pr_reviewer_history").
+		Set("removed_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"pr_id": prInternalID, "user_id": removedIDs, "removed_at": nil}).
+		Where(squirrel.NotEq{"user_id": assigned}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = q.Exec(ctx, sql, args...)
+	return err
+}
+
+// removedReviewers returns users who were removed from the pull request or reassigned away from it.
+func (r *PullRequestRepo) removedReviewers(ctx context.Context, pr *domain.PullRequest) ([]string, error) {
+	q := r.GetQueryer(ctx)
+
+	sql, args, err := r.Builder.
+		Select("u.user_id").
+		From("pr_reviewer_history h").
+		Join("pull_requests pr ON pr.id = h.pr_id").
+		Join("users u ON u.id = h.user_id").
+		Where(squirrel.Eq{"pr.pull_request_id": pr.PullRequestID}).
+		Where(squirrel.NotEq{"h.removed_at": nil}).
+		OrderBy("h.removed_at", "u.user_id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var removed []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		removed = append(removed, userID)
+	}
+
+	return removed, rows.Err()
+}
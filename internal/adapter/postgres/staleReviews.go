@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Egorrrad/avitotechBackendPR/internal/domain"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetStaleReviews returns reviewers of OPEN pull requests whose latest activity is before the given time,
+// an empty prID means all pull requests. Activity is taken from PR history, reviewers assigned before
+// history was recorded count from PR creation. A review already flagged stale after the latest activity
+// is skipped, so it is handled once until the reviewer acts again.
+func (r *PullRequestRepo) GetStaleReviews(ctx context.Context, before time.Time, prID string) ([]domain.StaleReview, error) {
+	q := r.GetQueryer(ctx)
+
+	openStatusID, err := r.toStatusID(domain.PullRequestStatusOPEN)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := r.Builder.
+		Select("pr.pull_request_id", "u.user_id", "act.last_at").
+		From("reviewers rv").
+		Join("pull_requests pr ON pr.id = rv.pr_id").
+		Join("users u ON u.id = rv.user_id").
+		JoinClause(`CROSS JOIN LATERAL (
+			SELECT COALESCE(MAX(e.created_at), pr.created_at) AS last_at
+			FROM pr_events e
+			WHERE e.pr_id = pr.id AND (
+				(e.event_type IN (?, ?) AND e.reviewer_id = u.user_id)
+				OR (e.event_type = ? AND e.replaced_by = u.user_id)
+				OR (e.event_type = ? AND e.to_status = ?))
+		) act`,
+			string(domain.PREventReviewerAssigned), string(domain.PREventReviewSubmitted),
+			string(domain.PREventReviewerReassigned),
+			string(domain.PREventStatusChanged), string(domain.PullRequestStatusOPEN)).
+		Where(squirrel.Eq{"pr.status": openStatusID}).
+		Where(squirrel.Lt{"act.last_at": before}).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM pr_events s
+			WHERE s.pr_id = pr.id AND s.event_type = ? AND s.reviewer_id = u.user_id
+				AND s.created_at >= act.last_at)`, string(domain.PREventReviewStale)).
+		OrderBy("pr.id", "u.user_id")
+	if prID != "" {
+		builder = builder.Where(squirrel.Eq{"pr.pull_request_id": prID})
+	}
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stale := make([]domain.StaleReview, 0)
+	for rows.Next() {
+		var s domain.StaleReview
+		if err := rows.Scan(&s.PullRequestID, &s.ReviewerID, &s.LastActivityAt); err != nil {
+			return nil, err
+		}
+		stale = append(stale, s)
+	}
+
+	return stale, rows.Err()
+}
+
+// LockOpen locks OPEN pull request row until the end of the transaction in ctx.
+// Returns false if it is no longer OPEN or another transaction holds the lock.
+func (r *PullRequestRepo) LockOpen(ctx context.Context, prID string) (bool, error) {
+	q := r.GetQueryer(ctx)
+
+	openStatusID, err := r.toStatusID(domain.PullRequestStatusOPEN)
+	if err != nil {
+		return false, err
+	}
+
+	sql, args, err := r.Builder.
+		Select("id").
+		From("pull_requests").
+		Where(squirrel.Eq{"pull_request_id": prID, "status": openStatusID}).
+		Suffix("FOR UPDATE SKIP LOCKED").
+		ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	var id int
+	err = q.QueryRow(ctx, sql, args...).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -2,10 +2,12 @@ package postgres
 
 import (
 	"context"
+	"errors"
 
 	"github.com/Egorrrad/avitotechBackendPR/internal/domain"
 	"github.com/Egorrrad/avitotechBackendPR/pkg/postgres"
 	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -22,15 +24,17 @@ func (r *TeamRepo) Create(ctx context.Context, team *domain.Team) error {
 
 	sql, args, err := r.Builder.
 		Insert("teams").
-		Columns("name").
-		Values(team.TeamName).
+		Columns("name", "parent_id").
+		Values(team.TeamName, squirrel.Expr("(SELECT id FROM teams WHERE name = ?)", team.ParentTeam)).
+		Suffix("RETURNING id").
 		ToSql()
 
 	if err != nil {
 		return err
 	}
 
-	_, err = q.Exec(ctx, sql, args...)
+	var teamID int
+	err = q.QueryRow(ctx, sql, args...).Scan(&teamID)
 
 	if err != nil {
 		if postgres.IsUniqueViolation(err) {
@@ -39,15 +43,127 @@ func (r *TeamRepo) Create(ctx context.Context, team *domain.Team) error {
 		return err
 	}
 
-	return nil
+	return r.upsertSettings(ctx, teamID, team.Settings)
+}
+
+func (r *TeamRepo) upsertSettings(ctx context.Context, teamID int, settings domain.TeamSettings) error {
+	q := r.GetQueryer(ctx)
+
+	sql, args, err := r.Builder.
+		Insert("team_settings").
+		Columns("team_id", "reviewer_strategy", "min_reviewers", "max_reviewers", "code_owners_mode",
+			"required_approvals", "block_changes_requested", "stale_action",
+			"extra_reviewers_high", "extra_reviewers_critical", "require_maintainer").
+		Values(teamID, nullableStrategy(settings.ReviewerStrategy), settings.MinReviewers, settings.MaxReviewers,
+			string(settings.CodeOwnersMode), settings.MergePolicy.RequiredApprovals,
+			settings.MergePolicy.BlockChangesRequested, string(settings.StaleReview.Action),
+			settings.PriorityRules.ExtraReviewersHigh, settings.PriorityRules.ExtraReviewersCritical,
+			settings.RequireMaintainer).
+		Suffix(`ON CONFLICT (team_id) DO UPDATE SET
+			reviewer_strategy = EXCLUDED.reviewer_strategy,
+			min_reviewers = EXCLUDED.min_reviewers,
+			max_reviewers = EXCLUDED.max_reviewers,
+			code_owners_mode = EXCLUDED.code_owners_mode,
+			required_approvals = EXCLUDED.required_approvals,
+			block_changes_requested = EXCLUDED.block_changes_requested,
+			stale_action = EXCLUDED.stale_action,
+			extra_reviewers_high = EXCLUDED.extra_reviewers_high,
+			extra_reviewers_critical = EXCLUDED.extra_reviewers_critical,
+			require_maintainer = EXCLUDED.require_maintainer`).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = q.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+
+	return r.replaceFallbacks(ctx, teamID, settings.FallbackTeams)
+}
+
+func (r *TeamRepo) replaceFallbacks(ctx context.Context, teamID int, fallbackTeams []string) error {
+	q := r.GetQueryer(ctx)
+
+	sql, args, err := r.Builder.Delete("team_fallbacks").Where(squirrel.Eq{"team_id": teamID}).ToSql()
+	if err != nil {
+		return err
+	}
+	if _, err = q.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+
+	if len(fallbackTeams) == 0 {
+		return nil
+	}
+
+	fallbackIDs, err := r.resolveTeamNames(ctx, fallbackTeams)
+	if err != nil {
+		return err
+	}
+
+	insert := r.Builder.Insert("team_fallbacks").Columns("team_id", "fallback_team_id", "position")
+	for i, name := range fallbackTeams {
+		insert = insert.Values(teamID, fallbackIDs[name], i)
+	}
+
+	sql, args, err = insert.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = q.Exec(ctx, sql, args...)
+	return err
+}
+
+// resolveTeamNames maps team names to internal ids, fails if any team is missing.
+func (r *TeamRepo) resolveTeamNames(ctx context.Context, names []string) (map[string]int, error) {
+	q := r.GetQueryer(ctx)
+
+	sql, args, err := r.Builder.Select("id", "name").From("teams").Where(squirrel.Eq{"name": names}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make(map[string]int, len(names))
+	for rows.Next() {
+		var (
+			id   int
+			name string
+		)
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, err
+		}
+		ids[name] = id
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	if len(ids) != len(names) {
+		return nil, domain.ErrTeamNotFound
+	}
+	return ids, nil
+}
+
+func nullableStrategy(strategy domain.ReviewerStrategy) pgtype.Text {
+	return pgtype.Text{String: string(strategy), Valid: strategy != ""}
 }
 
 func (r *TeamRepo) GetByName(ctx context.Context, name string) (*domain.Team, error) {
 	q := r.GetQueryer(ctx)
 
 	sql, args, err := r.Builder.
-		Select("t.name", "u.user_id", "u.username", "u.is_active").
+		Select("t.name", "COALESCE(p.name, '')", "t.archived", "u.user_id", "u.username", "u.is_active",
+			"u.max_open_reviews", "tm.role").
 		From("teams t").
+		LeftJoin("teams p ON t.parent_id = p.id").
 		LeftJoin("team_member tm ON t.id = tm.team_id").
 		LeftJoin("users u ON tm.user_id = u.id").
 		Where(squirrel.Eq{"t.name": name}).
@@ -67,29 +183,36 @@ func (r *TeamRepo) GetByName(ctx context.Context, name string) (*domain.Team, er
 	var userID pgtype.Text
 	var username pgtype.Text
 	var isActive pgtype.Bool
+	var maxOpen pgtype.Int4
+	var role pgtype.Text
 
 	teamFound := false
 
 	for rows.Next() {
-		var teamName string
+		var teamName, parentName string
+		var archived bool
 
-		err := rows.Scan(&teamName, &userID, &username, &isActive)
+		err := rows.Scan(&teamName, &parentName, &archived, &userID, &username, &isActive, &maxOpen, &role)
 		if err != nil {
 			return nil, err
 		}
 
 		if team == nil {
 			team = &domain.Team{
-				TeamName: teamName,
-				Members:  make([]domain.TeamMember, 0),
+				TeamName:   teamName,
+				ParentTeam: parentName,
+				Archived:   archived,
+				Members:    make([]domain.TeamMember, 0),
 			}
 		}
 
 		if userID.Valid {
 			team.Members = append(team.Members, domain.TeamMember{
-				UserID:   userID.String,
-				Username: username.String,
-				IsActive: isActive.Bool,
+				UserID:         userID.String,
+				Username:       username.String,
+				IsActive:       isActive.Bool,
+				MaxOpenReviews: nullableInt(maxOpen),
+				Role:           domain.TeamRole(role.String),
 			})
 		}
 		teamFound = true
@@ -103,6 +226,12 @@ func (r *TeamRepo) GetByName(ctx context.Context, name string) (*domain.Team, er
 		return nil, rows.Err()
 	}
 
+	settings, err := r.GetSettings(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	team.Settings = *settings
+
 	return team, nil
 }
 
@@ -126,3 +255,590 @@ func (r *TeamRepo) Exists(ctx context.Context, name string) (bool, error) {
 
 	return count > 0, nil
 }
+
+func (r *TeamRepo) GetSettings(ctx context.Context, name string) (*domain.TeamSettings, error) {
+	q := r.GetQueryer(ctx)
+
+	sql, args, err := r.Builder.
+		Select(
+			"ts.reviewer_strategy", "ts.min_reviewers", "ts.max_reviewers", "ts.code_owners_mode",
+			"ts.required_approvals", "ts.block_changes_requested", "ts.stale_action",
+			"ts.extra_reviewers_high", "ts.extra_reviewers_critical", "ts.require_maintainer",
+			`ARRAY(SELECT ft.name FROM team_fallbacks tf JOIN teams ft ON ft.id = tf.fallback_team_id
+				WHERE tf.team_id = t.id ORDER BY tf.position)`,
+		).
+		From("teams t").
+		LeftJoin("team_settings ts ON t.id = ts.team_id").
+		Where(squirrel.Eq{"t.name": name}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		strategy     pgtype.Text
+		minReviewers pgtype.Int4
+		maxReviewers pgtype.Int4
+		ownersMode   pgtype.Text
+		approvals    pgtype.Int4
+		blockChanges pgtype.Bool
+		staleAction  pgtype.Text
+		extraHigh    pgtype.Int4
+		extraCrit    pgtype.Int4
+		requireMaint pgtype.Bool
+		fallbacks    []string
+	)
+	err = q.QueryRow(ctx, sql, args...).Scan(&strategy, &minReviewers, &maxReviewers, &ownersMode,
+		&approvals, &blockChanges, &staleAction, &extraHigh, &extraCrit, &requireMaint, &fallbacks)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrTeamNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	settings := domain.DefaultTeamSettings()
+	settings.ReviewerStrategy = domain.ReviewerStrategy(strategy.String)
+	if minReviewers.Valid {
+		settings.MinReviewers = int(minReviewers.Int32)
+	}
+	if maxReviewers.Valid {
+		settings.MaxReviewers = int(maxReviewers.Int32)
+	}
+	if ownersMode.Valid {
+		settings.CodeOwnersMode = domain.CodeOwnersMode(ownersMode.String)
+	}
+	settings.MergePolicy.RequiredApprovals = int(approvals.Int32)
+	settings.MergePolicy.BlockChangesRequested = blockChanges.Bool
+	if staleAction.Valid {
+		settings.StaleReview.Action = domain.StaleReviewAction(staleAction.String)
+	}
+	settings.PriorityRules.ExtraReviewersHigh = int(extraHigh.Int32)
+	settings.PriorityRules.ExtraReviewersCritical = int(extraCrit.Int32)
+	settings.RequireMaintainer = requireMaint.Bool
+	if fallbacks != nil {
+		settings.FallbackTeams = fallbacks
+	}
+
+	return &settings, nil
+}
+
+func (r *TeamRepo) UpdateSettings(ctx context.Context, name string, settings *domain.TeamSettings) error {
+	teamID, err := r.getTeamInternalID(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	return r.upsertSettings(ctx, teamID, *settings)
+}
+
+func (r *TeamRepo) getTeamInternalID(ctx context.Context, name string) (int, error) {
+	q := r.GetQueryer(ctx)
+
+	sql, args, err := r.Builder.Select("id").From("teams").Where(squirrel.Eq{"name": name}).ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var teamID int
+	err = q.QueryRow(ctx, sql, args...).Scan(&teamID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, domain.ErrTeamNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+	return teamID, nil
+}
+
+// AdvanceCursor moves team round-robin cursor by step and returns its previous position.
+func (r *TeamRepo) AdvanceCursor(ctx context.Context, name string, step int) (int64, error) {
+	q := r.GetQueryer(ctx)
+
+	sql := `INSERT INTO reviewer_cursors (team_id, position)
+		SELECT id, $2 FROM teams WHERE name = $1
+		ON CONFLICT (team_id) DO UPDATE SET position = reviewer_cursors.position + EXCLUDED.position
+		RETURNING position`
+
+	var position int64
+	err := q.QueryRow(ctx, sql, name, step).Scan(&position)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, domain.ErrTeamNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return position - int64(step), nil
+}
+
+// AddMembers links users to the team with their roles keeping their other memberships,
+// roles of existing members are replaced, the team becomes primary for users who had no team.
+func (r *TeamRepo) AddMembers(ctx context.Context, name string, members []domain.TeamMember) error {
+	if len(members) == 0 {
+		return nil
+	}
+
+	q := r.GetQueryer(ctx)
+
+	teamID, err := r.getTeamInternalID(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	userIDs := make([]string, 0, len(members))
+	for _, m := range members {
+		userIDs = append(userIDs, m.UserID)
+	}
+
+	internalIDs, err := r.resolveUserIDs(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+
+	insert := r.Builder.Insert("team_member").Columns("team_id", "user_id", "role")
+	for i, id := range internalIDs {
+		insert = insert.Values(teamID, id, string(members[i].Role))
+	}
+
+	sql, args, err := insert.Suffix("ON CONFLICT (team_id, user_id) DO UPDATE SET role = EXCLUDED.role").ToSql()
+	if err != nil {
+		return err
+	}
+	if _, err = q.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+
+	return r.ensurePrimary(ctx, internalIDs)
+}
+
+// SetPrimary makes the team primary for user, who must already be its member.
+func (r *TeamRepo) SetPrimary(ctx context.Context, name, userID string) error {
+	q := r.GetQueryer(ctx)
+
+	teamID, err := r.getTeamInternalID(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	internalIDs, err := r.resolveUserIDs(ctx, []string{userID})
+	if err != nil {
+		return err
+	}
+
+	// cleared first, the partial unique index allows one primary per user at any moment
+	sql, args, err := r.Builder.
+		Update("team_member").
+		Set("is_primary", false).
+		Where(squirrel.Eq{"user_id": internalIDs[0], "is_primary": true}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	if _, err = q.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+
+	sql, args, err = r.Builder.
+		Update("team_member").
+		Set("is_primary", true).
+		Where(squirrel.Eq{"team_id": teamID, "user_id": internalIDs[0]}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	tag, err := q.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotTeamMember
+	}
+	return nil
+}
+
+// ensurePrimary marks the oldest remaining team primary for users who have teams but no primary one.
+func (r *TeamRepo) ensurePrimary(ctx context.Context, userIDs []int) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	q := r.GetQueryer(ctx)
+
+	sql, args, err := r.Builder.
+		Update("team_member").
+		Set("is_primary", true).
+		Where(`(user_id, team_id) IN (SELECT user_id, MIN(team_id) FROM team_member
+			WHERE user_id = ANY(?) GROUP BY user_id HAVING NOT bool_or(is_primary))`, userIDs).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = q.Exec(ctx, sql, args...)
+	return err
+}
+
+// RemoveMember unlinks user from the team, the user itself is kept.
+func (r *TeamRepo) RemoveMember(ctx context.Context, name, userID string) error {
+	q := r.GetQueryer(ctx)
+
+	teamID, err := r.getTeamInternalID(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	internalIDs, err := r.resolveUserIDs(ctx, []string{userID})
+	if err != nil {
+		return err
+	}
+
+	sql, args, err := r.Builder.
+		Delete("team_member").
+		Where(squirrel.Eq{"team_id": teamID, "user_id": internalIDs[0]}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	tag, err := q.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotTeamMember
+	}
+
+	return r.ensurePrimary(ctx, internalIDs)
+}
+
+// Rename changes team name, code owner rules referencing the team follow it.
+func (r *TeamRepo) Rename(ctx context.Context, name, newName string) error {
+	q := r.GetQueryer(ctx)
+
+	sql, args, err := r.Builder.Update("teams").Set("name", newName).Where(squirrel.Eq{"name": name}).ToSql()
+	if err != nil {
+		return err
+	}
+
+	tag, err := q.Exec(ctx, sql, args...)
+	if err != nil {
+		if postgres.IsUniqueViolation(err) {
+			return domain.ErrTeamAlreadyExists
+		}
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrTeamNotFound
+	}
+
+	sql, args, err = r.Builder.
+		Update("code_owner_rules").
+		Set("owner_teams", squirrel.Expr("array_replace(owner_teams, ?, ?)", name, newName)).
+		Where("? = ANY(owner_teams)", name).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = q.Exec(ctx, sql, args...)
+	return err
+}
+
+// Delete removes the team with its settings, memberships and round-robin cursor.
+// The team is dropped from fallback lists and code owner rules of others,
+// members for whom it was primary get another one of their teams as primary.
+func (r *TeamRepo) Delete(ctx context.Context, name string) error {
+	q := r.GetQueryer(ctx)
+
+	teamID, err := r.getTeamInternalID(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	memberIDs, err := r.memberIDs(ctx, teamID)
+	if err != nil {
+		return err
+	}
+
+	deletes := []squirrel.DeleteBuilder{
+		r.Builder.Delete("team_member").Where(squirrel.Eq{"team_id": teamID}),
+		r.Builder.Delete("team_settings").Where(squirrel.Eq{"team_id": teamID}),
+		r.Builder.Delete("reviewer_cursors").Where(squirrel.Eq{"team_id": teamID}),
+		r.Builder.Delete("team_fallbacks").Where(squirrel.Or{
+			squirrel.Eq{"team_id": teamID}, squirrel.Eq{"fallback_team_id": teamID},
+		}),
+		r.Builder.Delete("teams").Where(squirrel.Eq{"id": teamID}),
+	}
+	for _, d := range deletes {
+		sql, args, err := d.ToSql()
+		if err != nil {
+			return err
+		}
+		if _, err = q.Exec(ctx, sql, args...); err != nil {
+			return err
+		}
+	}
+
+	sql, args, err := r.Builder.
+		Update("code_owner_rules").
+		Set("owner_teams", squirrel.Expr("array_remove(owner_teams, ?)", name)).
+		Where("? = ANY(owner_teams)", name).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	if _, err = q.Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+
+	return r.ensurePrimary(ctx, memberIDs)
+}
+
+func (r *TeamRepo) memberIDs(ctx context.Context, teamID int) ([]int, error) {
+	q := r.GetQueryer(ctx)
+
+	sql, args, err := r.Builder.Select("user_id").From("team_member").Where(squirrel.Eq{"team_id": teamID}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// resolveUserIDs maps external user ids to internal ones preserving order, fails if any user is missing.
+func (r *TeamRepo) resolveUserIDs(ctx context.Context, userIDs []string) ([]int, error) {
+	q := r.GetQueryer(ctx)
+
+	sql, args, err := r.Builder.Select("id", "user_id").From("users").Where(squirrel.Eq{"user_id": userIDs}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make(map[string]int, len(userIDs))
+	for rows.Next() {
+		var (
+			id         int
+			externalID string
+		)
+		if err := rows.Scan(&id, &externalID); err != nil {
+			return nil, err
+		}
+		ids[externalID] = id
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	internalIDs := make([]int, 0, len(userIDs))
+	for _, userID := range userIDs {
+		id, ok := ids[userID]
+		if !ok {
+			return nil, domain.ErrUserNotFound
+		}
+		internalIDs = append(internalIDs, id)
+	}
+	return internalIDs, nil
+}
+
+// GetMembersByRole returns ids of the team's members with any of the roles, active or not.
+func (r *TeamRepo) GetMembersByRole(ctx context.Context, name string, roles ...domain.TeamRole) ([]string, error) {
+	roleNames := make([]string, 0, len(roles))
+	for _, role := range roles {
+		roleNames = append(roleNames, string(role))
+	}
+
+	sql, args, err := r.Builder.
+		Select("u.user_id").
+		From("team_member tm").
+		Join("teams t ON t.id = tm.team_id").
+		Join("users u ON u.id = tm.user_id").
+		Where(squirrel.Eq{"t.name": name, "tm.role": roleNames}).
+		OrderBy("u.user_id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.queryStrings(ctx, sql, args)
+}
+
+// SetParent moves the team under parent, empty parent makes it top-level.
+func (r *TeamRepo) SetParent(ctx context.Context, name, parent string) error {
+	q := r.GetQueryer(ctx)
+
+	var parentID *int
+	if parent != "" {
+		id, err := r.getTeamInternalID(ctx, parent)
+		if err != nil {
+			return err
+		}
+		parentID = &id
+	}
+
+	sql, args, err := r.Builder.Update("teams").Set("parent_id", parentID).Where(squirrel.Eq{"name": name}).ToSql()
+	if err != nil {
+		return err
+	}
+
+	tag, err := q.Exec(ctx, sql, args...)
+	if err != nil {
+		if postgres.IsCheckViolation(err) {
+			return domain.ErrTeamCycle
+		}
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrTeamNotFound
+	}
+	return nil
+}
+
+// SetArchived marks the team archived or brings it back in use.
+func (r *TeamRepo) SetArchived(ctx context.Context, name string, archived bool) error {
+	q := r.GetQueryer(ctx)
+
+	sql, args, err := r.Builder.Update("teams").Set("archived", archived).Where(squirrel.Eq{"name": name}).ToSql()
+	if err != nil {
+		return err
+	}
+
+	tag, err := q.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrTeamNotFound
+	}
+	return nil
+}
+
+// GetAncestors returns names of the team's ancestors, parent first.
+func (r *TeamRepo) GetAncestors(ctx context.Context, name string) ([]string, error) {
+	sql, args, err := r.Builder.
+		Select("name").
+		Prefix(`WITH RECURSIVE ancestors (id, name, parent_id, depth) AS (
+			SELECT p.id, p.name, p.parent_id, 1 FROM teams t JOIN teams p ON p.id = t.parent_id WHERE t.name = ?
+			UNION ALL
+			SELECT p.id, p.name, p.parent_id, a.depth + 1 FROM teams p JOIN ancestors a ON p.id = a.parent_id
+		)`, name).
+		From("ancestors").
+		OrderBy("depth").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.queryStrings(ctx, sql, args)
+}
+
+// GetChildren returns names of the teams directly under the team.
+func (r *TeamRepo) GetChildren(ctx context.Context, name string) ([]string, error) {
+	sql, args, err := r.Builder.
+		Select("c.name").
+		From("teams c").
+		Join("teams t ON c.parent_id = t.id").
+		Where(squirrel.Eq{"t.name": name}).
+		OrderBy("c.name").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.queryStrings(ctx, sql, args)
+}
+
+// GetSubtree returns the team with all teams nested in it, children sorted by name.
+func (r *TeamRepo) GetSubtree(ctx context.Context, name string) (*domain.TeamTree, error) {
+	q := r.GetQueryer(ctx)
+
+	sql, args, err := r.Builder.
+		Select("name", "parent_name").
+		Prefix(`WITH RECURSIVE subtree (id, name, parent_name) AS (
+			SELECT id, name, ''::VARCHAR FROM teams WHERE name = ?
+			UNION ALL
+			SELECT c.id, c.name, s.name FROM teams c JOIN subtree s ON c.parent_id = s.id
+		)`, name).
+		From("subtree").
+		OrderBy("name").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	children := make(map[string][]string)
+	found := false
+	for rows.Next() {
+		var teamName, parentName string
+		if err := rows.Scan(&teamName, &parentName); err != nil {
+			return nil, err
+		}
+		if teamName == name {
+			found = true
+			continue
+		}
+		children[parentName] = append(children[parentName], teamName)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	if !found {
+		return nil, domain.ErrTeamNotFound
+	}
+
+	return buildTeamTree(name, children), nil
+}
+
+func buildTeamTree(name string, children map[string][]string) *domain.TeamTree {
+	tree := &domain.TeamTree{TeamName: name, Children: make([]domain.TeamTree, 0, len(children[name]))}
+	for _, child := range children[name] {
+		tree.Children = append(tree.Children, *buildTeamTree(child, children))
+	}
+	return tree
+}
+
+// queryStrings runs a query selecting a single text column.
+func (r *TeamRepo) queryStrings(ctx context.Context, sql string, args []any) ([]string, error) {
+	rows, err := r.GetQueryer(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := make([]string, 0)
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
@@ -19,34 +19,8 @@ func NewUserRepo(pg *postgres.Postgres) *UserRepo {
 	return &UserRepo{pg}
 }
 
-type teamInternalID struct {
-	ID   int
-	Name string
-}
-
-func (r *UserRepo) resolveTeamNameToInternalID(ctx context.Context, teamName string) (*teamInternalID, error) {
-	q := r.GetQueryer(ctx)
-	var t teamInternalID
-
-	sql, args, err := r.Builder.
-		Select("id", "name").
-		From("teams").
-		Where(squirrel.Eq{"name": teamName}).
-		ToSql()
-	if err != nil {
-		return nil, err
-	}
-
-	err = q.QueryRow(ctx, sql, args...).Scan(&t.ID, &t.Name)
-	if errors.Is(err, pgx.ErrNoRows) {
-		return nil, domain.ErrTeamNotFound
-	}
-	if err != nil {
-		return nil, err
-	}
-	return &t, nil
-}
-
+// UpsertBatch creates or updates users, team membership is managed by TeamRepo.
+// max_open_reviews of an existing user is kept when the new value is nil.
 func (r *UserRepo) UpsertBatch(ctx context.Context, users []domain.User) error {
 	if len(users) == 0 {
 		return nil
@@ -54,115 +28,100 @@ func (r *UserRepo) UpsertBatch(ctx context.Context, users []domain.User) error {
 
 	q := r.GetQueryer(ctx)
 
-	teamName := users[0].TeamName
-	team, err := r.resolveTeamNameToInternalID(ctx, teamName)
-	if err != nil {
-		return err
-	}
-
 	upsert := r.Builder.
 		Insert("users").
-		Columns("user_id", "username", "is_active")
-
-	userExternalToInternalID := make(map[string]int)
+		Columns("user_id", "username", "is_active", "max_open_reviews")
 
 	for _, u := range users {
-		upsert = upsert.Values(u.UserID, u.Username, u.IsActive)
+		upsert = upsert.Values(u.UserID, u.Username, u.IsActive, u.MaxOpenReviews)
 	}
 
 	sql, args, err := upsert.
-		Suffix("ON CONFLICT (user_id) DO UPDATE SET username = EXCLUDED.username, is_active = EXCLUDED.is_active RETURNING id, user_id").
+		Suffix("ON CONFLICT (user_id) DO UPDATE SET username = EXCLUDED.username, is_active = EXCLUDED.is_active, " +
+			"max_open_reviews = COALESCE(EXCLUDED.max_open_reviews, users.max_open_reviews)").
 		ToSql()
 
 	if err != nil {
 		return err
 	}
 
-	rows, err := q.Query(ctx, sql, args...)
-	if err != nil {
-		return err
-	}
-	defer rows.Close()
+	_, err = q.Exec(ctx, sql, args...)
+	return err
+}
 
-	var internalID int
-	var externalID string
-	for rows.Next() {
-		errRow := rows.Scan(&internalID, &externalID)
-		if errRow != nil {
-			return errRow
-		}
-		userExternalToInternalID[externalID] = internalID
-	}
-	if rows.Err() != nil {
-		return rows.Err()
-	}
+// userTeamsColumn selects names of the user's teams, primary team first.
+const userTeamsColumn = `ARRAY(SELECT t.name FROM team_member tm JOIN teams t ON tm.team_id = t.id
+	WHERE tm.user_id = u.id ORDER BY tm.is_primary DESC, t.name)`
 
-	userInternalIDs := make([]int, 0, len(users))
-	for _, u := range users {
-		userInternalIDs = append(userInternalIDs, userExternalToInternalID[u.UserID])
-	}
+func (r *UserRepo) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	q := r.GetQueryer(ctx)
 
-	deleteSQL, deleteArgs, err := r.Builder.
-		Delete("team_member").
-		Where(squirrel.Eq{"user_id": userInternalIDs}).
+	sql, args, err := r.Builder.
+		Select("u.user_id", "u.username", "u.is_active", "u.max_open_reviews", userTeamsColumn).
+		From("users u").
+		Where(squirrel.Eq{"u.user_id": id}).
 		ToSql()
 	if err != nil {
-		return err
-	}
-	_, err = q.Exec(ctx, deleteSQL, deleteArgs...)
-	if err != nil {
-		return err
+		return nil, err
 	}
 
-	linkInsert := r.Builder.Insert("team_member").Columns("team_id", "user_id")
-	for _, internalID := range userInternalIDs {
-		linkInsert = linkInsert.Values(team.ID, internalID)
+	var user domain.User
+	var maxOpen pgtype.Int4
+	err = q.QueryRow(ctx, sql, args...).Scan(&user.UserID, &user.Username, &user.IsActive, &maxOpen, &user.Teams)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
 	}
-
-	insertSQL, insertArgs, err := linkInsert.ToSql()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = q.Exec(ctx, insertSQL, insertArgs...)
-	if err != nil {
-		return err
+	// primary team goes first, a user without teams has none
+	if len(user.Teams) > 0 {
+		user.TeamName = user.Teams[0]
 	}
+	user.MaxOpenReviews = nullableInt(maxOpen)
 
-	return nil
+	return &user, nil
 }
 
-func (r *UserRepo) GetByID(ctx context.Context, id string) (*domain.User, error) {
+// GetByIDs loads users in one query, unknown ids are skipped.
+func (r *UserRepo) GetByIDs(ctx context.Context, ids []string) ([]domain.User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
 	q := r.GetQueryer(ctx)
 
 	sql, args, err := r.Builder.
-		Select("u.user_id", "u.username", "u.is_active", "t.name").
+		Select("u.user_id", "u.username", "u.is_active", "u.max_open_reviews", userTeamsColumn).
 		From("users u").
-		LeftJoin("team_member tm ON u.id = tm.user_id").
-		LeftJoin("teams t ON tm.team_id = t.id").
-		Where(squirrel.Eq{"u.user_id": id}).
+		Where(squirrel.Eq{"u.user_id": ids}).
 		ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	var user domain.User
-	var teamName pgtype.Text
-	err = q.QueryRow(ctx, sql, args...).Scan(&user.UserID, &user.Username, &user.IsActive, &teamName)
-	if errors.Is(err, pgx.ErrNoRows) {
-		return nil, nil
-	}
+	rows, err := q.Query(ctx, sql, args...)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	if teamName.Valid {
-		user.TeamName = teamName.String
-	} else {
-		user.TeamName = ""
+	var users []domain.User
+	for rows.Next() {
+		var user domain.User
+		var maxOpen pgtype.Int4
+		if err := rows.Scan(&user.UserID, &user.Username, &user.IsActive, &maxOpen, &user.Teams); err != nil {
+			return nil, err
+		}
+		if len(user.Teams) > 0 {
+			user.TeamName = user.Teams[0]
+		}
+		user.MaxOpenReviews = nullableInt(maxOpen)
+		users = append(users, user)
 	}
 
-	return &user, nil
+	return users, rows.Err()
 }
 
 func (r *UserRepo) Update(ctx context.Context, user *domain.User) error {
@@ -172,6 +131,7 @@ func (r *UserRepo) Update(ctx context.Context, user *domain.User) error {
 		Update("users").
 		Set("username", user.Username).
 		Set("is_active", user.IsActive).
+		Set("max_open_reviews", user.MaxOpenReviews).
 		Where(squirrel.Eq{"user_id": user.UserID}).
 		ToSql()
 	if err != nil {
@@ -191,11 +151,12 @@ func (r *UserRepo) GetByTeamActive(ctx context.Context, teamName string) ([]doma
 	q := r.GetQueryer(ctx)
 
 	sql, args, err := r.Builder.
-		Select("u.user_id", "u.username", "u.is_active", "t.name").
+		Select("u.user_id", "u.username", "u.is_active", "u.max_open_reviews", "t.name").
 		From("users u").
 		Join("team_member tm ON u.id = tm.user_id").
 		Join("teams t ON tm.team_id = t.id").
 		Where(squirrel.Eq{"t.name": teamName, "u.is_active": true}).
+		Where(availableNow).
 		ToSql()
 	if err != nil {
 		return nil, err
@@ -211,11 +172,13 @@ func (r *UserRepo) GetByTeamActive(ctx context.Context, teamName string) ([]doma
 	for rows.Next() {
 		var user domain.User
 		var tName string
-		err := rows.Scan(&user.UserID, &user.Username, &user.IsActive, &tName)
+		var maxOpen pgtype.Int4
+		err := rows.Scan(&user.UserID, &user.Username, &user.IsActive, &maxOpen, &tName)
 		if err != nil {
 			return nil, err
 		}
 		user.TeamName = tName
+		user.MaxOpenReviews = nullableInt(maxOpen)
 		users = append(users, user)
 	}
 
@@ -225,3 +188,48 @@ func (r *UserRepo) GetByTeamActive(ctx context.Context, teamName string) ([]doma
 
 	return users, nil
 }
+
+func (r *UserRepo) GetActiveByIDs(ctx context.Context, ids []string) ([]domain.User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	q := r.GetQueryer(ctx)
+
+	sql, args, err := r.Builder.
+		Select("u.user_id", "u.username", "u.is_active", "u.max_open_reviews").
+		From("users u").
+		Where(squirrel.Eq{"u.user_id": ids, "u.is_active": true}).
+		Where(availableNow).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []domain.User
+	for rows.Next() {
+		var user domain.User
+		var maxOpen pgtype.Int4
+		if err := rows.Scan(&user.UserID, &user.Username, &user.IsActive, &maxOpen); err != nil {
+			return nil, err
+		}
+		user.MaxOpenReviews = nullableInt(maxOpen)
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+func nullableInt(v pgtype.Int4) *int {
+	if !v.Valid {
+		return nil
+	}
+	n := int(v.Int32)
+	return &n
+}
@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Egorrrad/avitotechBackendPR/internal/domain"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// List returns a page of users matching params sorted by username and cursor of the next page.
+// Limit is expected to be validated by the caller.
+func (r *UserRepo) List(ctx context.Context, params domain.GetUsersListParams) ([]domain.User, string, error) {
+	q := r.GetQueryer(ctx)
+
+	builder := r.Builder.
+		Select("u.id", "u.user_id", "u.username", "u.is_active", "u.max_open_reviews",
+			`ARRAY(SELECT t.name FROM team_member tm JOIN teams t ON tm.team_id = t.id
+				WHERE tm.user_id = u.id ORDER BY tm.is_primary DESC, t.name)`).
+		From("users u").
+		OrderBy("u.username", "u.id").
+		Limit(uint64(params.Limit) + 1)
+
+	if params.TeamName != "" {
+		builder = builder.Where(`EXISTS (SELECT 1 FROM team_member tm JOIN teams t ON t.id = tm.team_id
+			WHERE tm.user_id = u.id AND t.name = ?)`, params.TeamName)
+	}
+	if params.IsActive != nil {
+		builder = builder.Where(squirrel.Eq{"u.is_active": *params.IsActive})
+	}
+	if params.UsernamePrefix != "" {
+		builder = builder.Where("lower(u.username) LIKE ?", strings.ToLower(escapeLike(params.UsernamePrefix))+"%")
+	}
+
+	if params.Cursor != "" {
+		cursor, err := decodeCursor(params.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		if cursor.SortBy != "" {
+			return nil, "", domain.ErrInvalidListParams
+		}
+		builder = builder.Where("(u.username, u.id) > (?, ?)", cursor.Value, cursor.ID)
+	}
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		return nil, "", err
+	}
+
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	var (
+		users   = make([]domain.User, 0, params.Limit)
+		lastID  int
+		hasMore bool
+	)
+	for rows.Next() {
+		// the extra row only tells there is a next page
+		if len(users) == params.Limit {
+			hasMore = true
+			break
+		}
+
+		var (
+			user    domain.User
+			id      int
+			maxOpen pgtype.Int4
+		)
+		err := rows.Scan(&id, &user.UserID, &user.Username, &user.IsActive, &maxOpen, &user.Teams)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if len(user.Teams) > 0 {
+			user.TeamName = user.Teams[0]
+		}
+		user.MaxOpenReviews = nullableInt(maxOpen)
+
+		users = append(users, user)
+		lastID = id
+	}
+	if rows.Err() != nil {
+		return nil, "", rows.Err()
+	}
+
+	var next string
+	if hasMore {
+		next = encodeCursor(listCursor{Value: users[len(users)-1].Username, ID: lastID})
+	}
+
+	return users, next, nil
+}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"os"
 	"os/signal"
 	"syscall"
@@ -8,6 +9,8 @@ import (
 	"github.com/Egorrrad/avitotechBackendPR/config"
 	repo "github.com/Egorrrad/avitotechBackendPR/internal/adapter/postgres"
 	"github.com/Egorrrad/avitotechBackendPR/internal/controller/http"
+	"github.com/Egorrrad/avitotechBackendPR/internal/controller/worker"
+	"github.com/Egorrrad/avitotechBackendPR/internal/domain"
 	"github.com/Egorrrad/avitotechBackendPR/internal/usecase"
 	"github.com/Egorrrad/avitotechBackendPR/pkg/httpserver"
 	"github.com/Egorrrad/avitotechBackendPR/pkg/logger"
@@ -37,13 +40,47 @@ func Run(cfg *config.Config) {
 		l.Fatal("app - Run - repo.NewPullRequestRepo", "error", err)
 	}
 
+	strategy := domain.ReviewerStrategy(cfg.Assignment.Strategy)
+	if !strategy.IsValid() {
+		l.Fatal("app - Run - unknown assignment strategy", "strategy", cfg.Assignment.Strategy)
+	}
+
 	// UseCase
 	prsUseCase := usecase.NewService(
 		repo.NewTeamRepo(pg),
 		repo.NewUserRepo(pg),
 		pr,
+		repo.NewCodeOwnerRepo(pg),
+		repo.NewUnavailabilityRepo(pg),
+		pg,
+		usecase.DefaultStrategy(strategy),
+		usecase.Deterministic(cfg.Assignment.Deterministic),
+		usecase.ReassignOnDeactivate(cfg.Assignment.ReassignOnDeactivate),
+		usecase.MaxReassignments(cfg.Assignment.MaxReassignments),
+		usecase.HierarchyEscalation(cfg.Assignment.HierarchyEscalation),
+		usecase.Admins(cfg.Access.AdminUserIDs...),
+		usecase.RestrictReviewerEdits(cfg.Access.RestrictReviewerEdits),
 	)
 
+	if cfg.CodeOwners.File != "" {
+		var content []byte
+		if content, err = os.ReadFile(cfg.CodeOwners.File); err != nil {
+			l.Fatal("app - Run - os.ReadFile", "error", err)
+		}
+
+		if _, err = prsUseCase.ImportCodeOwners(context.Background(), string(content)); err != nil {
+			l.Fatal("app - Run - prsUseCase.ImportCodeOwners", "error", err)
+		}
+	}
+
+	// Stale review worker
+	var staleReviews *worker.StaleReviews
+	if cfg.StaleReview.Enabled {
+		staleReviews = worker.NewStaleReviews(prsUseCase, l, cfg.StaleReview.SLA, cfg.StaleReview.Interval,
+			cfg.StaleReview.DryRun)
+		staleReviews.Start()
+	}
+
 	// HTTP Router (Chi)
 	router := http.NewRouter(cfg, prsUseCase, l)
 
@@ -69,4 +106,8 @@ func Run(cfg *config.Config) {
 	if err != nil {
 		l.Error("app - Run - httpServer.Shutdown", "error", err)
 	}
+
+	if staleReviews != nil {
+		staleReviews.Stop()
+	}
 }
@@ -0,0 +1,83 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Egorrrad/avitotechBackendPR/internal/domain"
+)
+
+// Добавить период недоступности пользователя (отпуск, больничный)
+// (POST /users/unavailability/add)
+func (h *Handler) PostUsersUnavailabilityAdd(w http.ResponseWriter, r *http.Request) {
+	var req domain.PostUsersUnavailabilityAddJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, domain.NOTFOUND, "Invalid request format")
+		return
+	}
+
+	ctx := r.Context()
+	period, err := h.service.AddUnavailability(ctx, &req)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, period)
+}
+
+// Получить периоды недоступности пользователя
+// (GET /users/unavailability/list)
+func (h *Handler) GetUsersUnavailabilityList(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+
+	ctx := r.Context()
+	periods, err := h.service.GetUserUnavailability(ctx, userID)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, periods)
+}
+
+// Изменить период недоступности
+// (POST /users/unavailability/update)
+func (h *Handler) PostUsersUnavailabilityUpdate(w http.ResponseWriter, r *http.Request) {
+	var req domain.PostUsersUnavailabilityUpdateJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, domain.NOTFOUND, "Invalid request format")
+		return
+	}
+
+	ctx := r.Context()
+	period, err := h.service.UpdateUnavailability(ctx, &req)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, period)
+}
+
+// Удалить период недоступности
+// (POST /users/unavailability/delete)
+func (h *Handler) PostUsersUnavailabilityDelete(w http.ResponseWriter, r *http.Request) {
+	var req domain.PostUsersUnavailabilityDeleteJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, domain.NOTFOUND, "Invalid request format")
+		return
+	}
+
+	ctx := r.Context()
+	period, err := h.service.DeleteUnavailability(ctx, req.ID)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, period)
+}
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Egorrrad/avitotechBackendPR/internal/domain"
+)
+
+// Получить правила владения кодом
+// (GET /codeOwners/get)
+func (h *Handler) GetCodeOwnersGet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rules, err := h.service.GetCodeOwners(ctx)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, rules)
+}
+
+// Заменить правила владения кодом
+// (POST /codeOwners/set)
+func (h *Handler) PostCodeOwnersSet(w http.ResponseWriter, r *http.Request) {
+	var req domain.PostCodeOwnersSetJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, domain.NOTFOUND, "Invalid request format")
+		return
+	}
+
+	ctx := r.Context()
+	rules, err := h.service.SetCodeOwners(ctx, req.Rules)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, rules)
+}
+
+// Заменить правила владения кодом содержимым файла CODEOWNERS
+// (POST /codeOwners/import)
+func (h *Handler) PostCodeOwnersImport(w http.ResponseWriter, r *http.Request) {
+	var req domain.PostCodeOwnersImportJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, domain.NOTFOUND, "Invalid request format")
+		return
+	}
+
+	ctx := r.Context()
+	rules, err := h.service.ImportCodeOwners(ctx, req.Content)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, rules)
+}
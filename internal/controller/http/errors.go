@@ -39,7 +39,75 @@ func (h *Handler) handleError(ctx context.Context, w http.ResponseWriter, err er
 		h.sendError(w, http.StatusConflict, domain.NOTASSIGNED, "user not assigned")
 	case errors.Is(err, domain.ErrChangeAfterMerge):
 		h.sendError(w, http.StatusConflict, domain.PRMERGED, "change after merge not allowed")
+	case errors.Is(err, domain.ErrInvalidReviewerStrategy):
+		h.sendError(w, http.StatusBadRequest, domain.BADREQUEST, "invalid reviewer strategy")
+	case errors.Is(err, domain.ErrInvalidTeamSettings):
+		h.sendError(w, http.StatusBadRequest, domain.BADREQUEST, "invalid team settings")
+	case errors.Is(err, domain.ErrNotEnoughReviewers):
+		h.sendError(w, http.StatusConflict, domain.NOTENOUGH, "not enough reviewers available")
+	case errors.Is(err, domain.ErrCodeOwnersUnavailable):
+		h.sendError(w, http.StatusConflict, domain.NOOWNER, "no available code owner for changed paths")
+	case errors.Is(err, domain.ErrInvalidCodeOwners):
+		h.sendError(w, http.StatusBadRequest, domain.BADREQUEST, "invalid code owners rules")
+	case errors.Is(err, domain.ErrUnavailabilityNotFound):
+		h.sendError(w, http.StatusNotFound, domain.NOTFOUND, "unavailability period not found")
+	case errors.Is(err, domain.ErrInvalidUnavailability):
+		h.sendError(w, http.StatusBadRequest, domain.BADREQUEST, "ends_at must be after starts_at")
+	case errors.Is(err, domain.ErrEmptyUserSet):
+		h.sendError(w, http.StatusBadRequest, domain.BADREQUEST, "team_name or user_ids required")
+	case errors.Is(err, domain.ErrInvalidReviewCapacity):
+		h.sendError(w, http.StatusBadRequest, domain.BADREQUEST, "max_open_reviews must not be negative")
+	case errors.Is(err, domain.ErrReviewersAtCapacity):
+		h.sendError(w, http.StatusConflict, domain.ATCAPACITY, "all candidates are at review capacity")
+	case errors.Is(err, domain.ErrMergeBlocked):
+		var blocked *domain.MergeBlockedError
+		errors.As(err, &blocked)
+		h.respondJSON(w, http.StatusConflict, domain.ErrorResponse{
+			Error: domain.ErrorDetails{
+				Code:    domain.BLOCKED,
+				Message: "merge blocked by merge policy",
+				Details: blocked.Missing,
+			},
+		})
+	case errors.Is(err, domain.ErrForceActorRequired):
+		h.sendError(w, http.StatusBadRequest, domain.BADREQUEST, "actor is required for forced merge")
+	case errors.Is(err, domain.ErrForbidden):
+		h.sendError(w, http.StatusForbidden, domain.FORBIDDEN, "actor must be an admin, a maintainer or the lead of the team")
+	case errors.Is(err, domain.ErrInvalidListParams):
+		h.sendError(w, http.StatusBadRequest, domain.BADREQUEST, "invalid list parameters")
+	case errors.Is(err, domain.ErrNotReviewable):
+		h.sendError(w, http.StatusConflict, domain.REVIEWABLE, "pull request is not open for review")
+	case errors.Is(err, domain.ErrInvalidTransition):
+		h.sendError(w, http.StatusConflict, domain.TRANSITION, "status transition not allowed")
+	case errors.Is(err, domain.ErrInvalidReviewDecision):
+		h.sendError(w, http.StatusBadRequest, domain.BADREQUEST, "decision must be APPROVED, CHANGES_REQUESTED or COMMENTED")
 
+	case errors.Is(err, domain.ErrAuthorAsReviewer):
+		h.sendError(w, http.StatusBadRequest, domain.BADREQUEST, "author cannot be a reviewer")
+	case errors.Is(err, domain.ErrAlreadyReviewer):
+		h.sendError(w, http.StatusConflict, domain.ASSIGNED, "user already assigned")
+	case errors.Is(err, domain.ErrReviewerUnavailable):
+		h.sendError(w, http.StatusConflict, domain.UNAVAILABLE, "user is inactive or unavailable")
+	case errors.Is(err, domain.ErrReviewerLimitReached):
+		h.sendError(w, http.StatusConflict, domain.LIMIT, "max_reviewers of the team reached")
+	case errors.Is(err, domain.ErrReassignLimitReached):
+		h.sendError(w, http.StatusConflict, domain.REASSIGNS, "max reassignments per pull request reached")
+	case errors.Is(err, domain.ErrReservedActor):
+		h.sendError(w, http.StatusBadRequest, domain.BADREQUEST, "actor system is reserved")
+	case errors.Is(err, domain.ErrInvalidPriority):
+		h.sendError(w, http.StatusBadRequest, domain.BADREQUEST, "priority must be LOW, NORMAL, HIGH or CRITICAL")
+	case errors.Is(err, domain.ErrNotTeamMember):
+		h.sendError(w, http.StatusNotFound, domain.NOTFOUND, "user is not a member of the team")
+	case errors.Is(err, domain.ErrTeamNotEmpty):
+		h.sendError(w, http.StatusConflict, domain.NOTEMPTY, "team has members and is not archived")
+	case errors.Is(err, domain.ErrEmptyTeamName):
+		h.sendError(w, http.StatusBadRequest, domain.BADREQUEST, "team_name must not be empty")
+	case errors.Is(err, domain.ErrTeamCycle):
+		h.sendError(w, http.StatusBadRequest, domain.BADREQUEST, "team cannot be its own ancestor")
+	case errors.Is(err, domain.ErrInvalidRole):
+		h.sendError(w, http.StatusBadRequest, domain.BADREQUEST, "role must be MEMBER, MAINTAINER or LEAD")
+	case errors.Is(err, domain.ErrStaleScanBefore):
+		h.sendError(w, http.StatusBadRequest, domain.BADREQUEST, "before is required")
 	default:
 		h.sendError(w, http.StatusInternalServerError, domain.INTERNAL, "internal server error")
 	}
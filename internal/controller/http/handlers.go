@@ -20,22 +20,58 @@ type Service interface {
 	PullRequestService
 	TeamService
 	UserService
+	CodeOwnersService
 }
 
 type PullRequestService interface {
-	CreatePullRequest(ctx context.Context, prID, author, name string) (*domain.PullRequestResponse, error)
-	MergePullRequest(ctx context.Context, prID string) (*domain.PullRequestResponse, error)
-	ReassignReviewer(ctx context.Context, prID string, id2 string) (*domain.ReassignPRResponse, error)
+	CreatePullRequest(ctx context.Context, req *domain.PostPullRequestCreateJSONBody) (*domain.PullRequestResponse, error)
+	MergePullRequest(ctx context.Context, req *domain.PostPullRequestMergeJSONBody) (*domain.PullRequestResponse, error)
+	MarkReady(ctx context.Context, prID string) (*domain.PullRequestResponse, error)
+	ClosePullRequest(ctx context.Context, prID string) (*domain.PullRequestResponse, error)
+	ReopenPullRequest(ctx context.Context, prID string) (*domain.PullRequestResponse, error)
+	ReassignReviewer(ctx context.Context, req *domain.PostPullRequestReassignJSONBody) (*domain.ReassignPRResponse, error)
+	AddReviewer(ctx context.Context, req *domain.PostPullRequestAddReviewerJSONBody) (*domain.PullRequestResponse, error)
+	RemoveReviewer(ctx context.Context, req *domain.PostPullRequestRemoveReviewerJSONBody) (*domain.PullRequestResponse, error)
+	GetPullRequest(ctx context.Context, prID string) (*domain.PullRequestResponse, error)
+	GetPullRequestHistory(ctx context.Context, prID string) (*domain.PullRequestHistoryResponse, error)
+	ListPullRequests(ctx context.Context, params domain.GetPullRequestListParams) (*domain.PullRequestListResponse, error)
+	SubmitReview(ctx context.Context, req *domain.PostPullRequestReviewJSONBody) (*domain.PullRequestResponse, error)
+	ScanStaleReviews(ctx context.Context, req *domain.PostPullRequestScanStaleJSONBody) (*domain.StaleReviewsResponse, error)
 }
 
 type TeamService interface {
-	CreateTeam(ctx context.Context, teamName string, members []domain.TeamMember) (*domain.Team, error)
+	CreateTeam(ctx context.Context, teamName string, members []domain.TeamMember, settings domain.TeamSettings,
+		parentTeam string) (*domain.Team, error)
 	GetTeam(ctx context.Context, teamName string) (*domain.Team, error)
+	UpdateTeamSettings(ctx context.Context, teamName string, patch domain.TeamSettingsPatch) (*domain.Team, error)
+	AddTeamMembers(ctx context.Context, req *domain.PostTeamAddMemberJSONBody) (*domain.Team, error)
+	RemoveTeamMember(ctx context.Context, req *domain.PostTeamRemoveMemberJSONBody) (*domain.TeamMemberRemovedResponse, error)
+	RenameTeam(ctx context.Context, req *domain.PostTeamRenameJSONBody) (*domain.Team, error)
+	DeleteTeam(ctx context.Context, req *domain.PostTeamDeleteJSONBody) (*domain.TeamDeletedResponse, error)
+	SetTeamParent(ctx context.Context, req *domain.PostTeamSetParentJSONBody) (*domain.Team, error)
+	SetTeamArchived(ctx context.Context, req *domain.PostTeamSetArchivedJSONBody) (*domain.Team, error)
+	GetTeamSubtree(ctx context.Context, teamName string) (*domain.TeamTree, error)
+	GetTeamAncestors(ctx context.Context, teamName string) (*domain.TeamAncestorsResponse, error)
 }
 
 type UserService interface {
+	GetUser(ctx context.Context, userID string) (*domain.UserDetailsResponse, error)
+	ListUsers(ctx context.Context, params domain.GetUsersListParams) (*domain.UserListResponse, error)
 	GetPrUserReviewer(ctx context.Context, userID string) (*domain.UserReviewsResponse, error)
-	UpdateUserActive(ctx context.Context, userID string, active bool) (*domain.UserUpdActiveResponse, error)
+	UpdateUserActive(ctx context.Context, req *domain.PostUsersSetIsActiveJSONBody) (*domain.UserUpdActiveResponse, error)
+	SetMaxOpenReviews(ctx context.Context, req *domain.PostUsersSetMaxOpenReviewsJSONBody) (*domain.UserResponse, error)
+	SetPrimaryTeam(ctx context.Context, req *domain.PostUsersSetPrimaryTeamJSONBody) (*domain.UserResponse, error)
+	DeactivateUsers(ctx context.Context, req *domain.PostUsersDeactivateBatchJSONBody) (*domain.DeactivateBatchResponse, error)
+	AddUnavailability(ctx context.Context, req *domain.PostUsersUnavailabilityAddJSONBody) (*domain.UnavailabilityResponse, error)
+	GetUserUnavailability(ctx context.Context, userID string) (*domain.UserUnavailabilityResponse, error)
+	UpdateUnavailability(ctx context.Context, req *domain.PostUsersUnavailabilityUpdateJSONBody) (*domain.UnavailabilityResponse, error)
+	DeleteUnavailability(ctx context.Context, id int64) (*domain.UnavailabilityResponse, error)
+}
+
+type CodeOwnersService interface {
+	GetCodeOwners(ctx context.Context) (*domain.CodeOwnersResponse, error)
+	SetCodeOwners(ctx context.Context, rules []domain.CodeOwnerRule) (*domain.CodeOwnersResponse, error)
+	ImportCodeOwners(ctx context.Context, content string) (*domain.CodeOwnersResponse, error)
 }
 
 func NewHTTPHandler(service Service,
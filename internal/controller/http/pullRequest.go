@@ -2,12 +2,16 @@ package http
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/Egorrrad/avitotechBackendPR/internal/domain"
 )
 
-// Создать PR и автоматически назначить до 2 ревьюверов из команды автора
+// Создать PR и автоматически назначить до max_reviewers ревьюверов из команды автора
 // (POST /pullRequest/create)
 func (h *Handler) PostPullRequestCreate(w http.ResponseWriter, r *http.Request) {
 	var req domain.PostPullRequestCreateJSONBody
@@ -18,7 +22,7 @@ func (h *Handler) PostPullRequestCreate(w http.ResponseWriter, r *http.Request)
 	}
 
 	ctx := r.Context()
-	pr, err := h.service.CreatePullRequest(ctx, req.PullRequestID, req.AuthorID, req.PullRequestName)
+	pr, err := h.service.CreatePullRequest(ctx, &req)
 	if err != nil {
 		h.handleError(ctx, w, err)
 		return
@@ -38,7 +42,7 @@ func (h *Handler) PostPullRequestMerge(w http.ResponseWriter, r *http.Request) {
 	}
 
 	ctx := r.Context()
-	mergedPr, err := h.service.MergePullRequest(ctx, req.PullRequestID)
+	mergedPr, err := h.service.MergePullRequest(ctx, &req)
 	if err != nil {
 		h.handleError(ctx, w, err)
 		return
@@ -47,6 +51,164 @@ func (h *Handler) PostPullRequestMerge(w http.ResponseWriter, r *http.Request) {
 	h.respondJSON(w, http.StatusOK, mergedPr)
 }
 
+// Перевести DRAFT PR в OPEN и назначить ревьюверов
+// (POST /pullRequest/ready)
+func (h *Handler) PostPullRequestReady(w http.ResponseWriter, r *http.Request) {
+	var req domain.PostPullRequestReadyJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, domain.NOTFOUND, "Invalid request format")
+		return
+	}
+
+	ctx := r.Context()
+	pr, err := h.service.MarkReady(ctx, req.PullRequestID)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, pr)
+}
+
+// Закрыть PR без слияния, ревьюверы освобождаются
+// (POST /pullRequest/close)
+func (h *Handler) PostPullRequestClose(w http.ResponseWriter, r *http.Request) {
+	var req domain.PostPullRequestCloseJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, domain.NOTFOUND, "Invalid request format")
+		return
+	}
+
+	ctx := r.Context()
+	pr, err := h.service.ClosePullRequest(ctx, req.PullRequestID)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, pr)
+}
+
+// Переоткрыть CLOSED PR, прежние ревьюверы возвращаются, если ещё доступны
+// (POST /pullRequest/reopen)
+func (h *Handler) PostPullRequestReopen(w http.ResponseWriter, r *http.Request) {
+	var req domain.PostPullRequestReopenJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, domain.NOTFOUND, "Invalid request format")
+		return
+	}
+
+	ctx := r.Context()
+	pr, err := h.service.ReopenPullRequest(ctx, req.PullRequestID)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, pr)
+}
+
+// Получить PR с ревьюверами, статусом и временными метками
+// (GET /pullRequest/get)
+func (h *Handler) GetPullRequestGet(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+
+	ctx := r.Context()
+	pr, err := h.service.GetPullRequest(ctx, prID)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, pr)
+}
+
+// Получить историю изменений PR, от старых событий к новым
+// (GET /pullRequest/history)
+func (h *Handler) GetPullRequestHistory(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+
+	ctx := r.Context()
+	history, err := h.service.GetPullRequestHistory(ctx, prID)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, history)
+}
+
+// Список PR с фильтрами, сортировкой и курсорной пагинацией
+// (GET /pullRequest/list)
+func (h *Handler) GetPullRequestList(w http.ResponseWriter, r *http.Request) {
+	params, err := parsePullRequestListParams(r.URL.Query())
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, domain.BADREQUEST, err.Error())
+		return
+	}
+
+	ctx := r.Context()
+	list, err := h.service.ListPullRequests(ctx, params)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, list)
+}
+
+func parsePullRequestListParams(query url.Values) (domain.GetPullRequestListParams, error) {
+	params := domain.GetPullRequestListParams{
+		Status:     domain.PullRequestStatus(query.Get("status")),
+		AuthorID:   query.Get("author_id"),
+		ReviewerID: query.Get("reviewer_id"),
+		TeamName:   query.Get("team_name"),
+		Name:       query.Get("name"),
+		SortBy:     domain.PullRequestSortField(query.Get("sort_by")),
+		Cursor:     query.Get("cursor"),
+		Desc:       true,
+	}
+
+	switch query.Get("order") {
+	case "", "desc":
+	case "asc":
+		params.Desc = false
+	default:
+		return params, fmt.Errorf("order must be asc or desc")
+	}
+
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return params, fmt.Errorf("limit must be an integer")
+		}
+		params.Limit = limit
+	}
+
+	timeParams := map[string]**time.Time{
+		"created_from": &params.CreatedFrom,
+		"created_to":   &params.CreatedTo,
+		"merged_from":  &params.MergedFrom,
+		"merged_to":    &params.MergedTo,
+	}
+	for name, dst := range timeParams {
+		v := query.Get(name)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return params, fmt.Errorf("%s must be RFC 3339 time", name)
+		}
+		*dst = &t
+	}
+
+	return params, nil
+}
+
 // Переназначить конкретного ревьювера на другого из его команды
 // (POST /pullRequest/reassign)
 func (h *Handler) PostPullRequestReassign(w http.ResponseWriter, r *http.Request) {
@@ -58,7 +220,7 @@ func (h *Handler) PostPullRequestReassign(w http.ResponseWriter, r *http.Request
 	}
 
 	ctx := r.Context()
-	reasigned, err := h.service.ReassignReviewer(ctx, req.PullRequestID, req.OldUserID)
+	reasigned, err := h.service.ReassignReviewer(ctx, &req)
 	if err != nil {
 		h.handleError(ctx, w, err)
 		return
@@ -66,3 +228,83 @@ func (h *Handler) PostPullRequestReassign(w http.ResponseWriter, r *http.Request
 
 	h.respondJSON(w, http.StatusOK, reasigned)
 }
+
+// Назначить конкретного пользователя ревьювером, в том числе из другой команды
+// (POST /pullRequest/addReviewer)
+func (h *Handler) PostPullRequestAddReviewer(w http.ResponseWriter, r *http.Request) {
+	var req domain.PostPullRequestAddReviewerJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, domain.NOTFOUND, "Invalid request format")
+		return
+	}
+
+	ctx := r.Context()
+	pr, err := h.service.AddReviewer(ctx, &req)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, pr)
+}
+
+// Снять ревьювера с PR без замены
+// (POST /pullRequest/removeReviewer)
+func (h *Handler) PostPullRequestRemoveReviewer(w http.ResponseWriter, r *http.Request) {
+	var req domain.PostPullRequestRemoveReviewerJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, domain.NOTFOUND, "Invalid request format")
+		return
+	}
+
+	ctx := r.Context()
+	pr, err := h.service.RemoveReviewer(ctx, &req)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, pr)
+}
+
+// Оставить решение ревьювера: одобрить, запросить изменения или только прокомментировать
+// (POST /pullRequest/review)
+func (h *Handler) PostPullRequestReview(w http.ResponseWriter, r *http.Request) {
+	var req domain.PostPullRequestReviewJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, domain.NOTFOUND, "Invalid request format")
+		return
+	}
+
+	ctx := r.Context()
+	pr, err := h.service.SubmitReview(ctx, &req)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, pr)
+}
+
+// Обработать зависшие ревью вручную, как это делает фоновый воркер
+// (POST /pullRequest/scanStale)
+func (h *Handler) PostPullRequestScanStale(w http.ResponseWriter, r *http.Request) {
+	var req domain.PostPullRequestScanStaleJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, domain.NOTFOUND, "Invalid request format")
+		return
+	}
+
+	ctx := r.Context()
+	resp, err := h.service.ScanStaleReviews(ctx, &req)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, resp)
+}
@@ -38,19 +38,57 @@ func NewRouter(cfg *config.Config, t *usecase.Service, l logger.Interface) http.
 	r.Route("/pullRequest", func(r chi.Router) {
 		r.Post("/create", h.PostPullRequestCreate)
 		r.Post("/merge", h.PostPullRequestMerge)
+		r.Get("/get", h.GetPullRequestGet)
+		r.Get("/list", h.GetPullRequestList)
+		r.Get("/history", h.GetPullRequestHistory)
+		r.Post("/ready", h.PostPullRequestReady)
+		r.Post("/close", h.PostPullRequestClose)
+		r.Post("/reopen", h.PostPullRequestReopen)
 		r.Post("/reassign", h.PostPullRequestReassign)
+		r.Post("/addReviewer", h.PostPullRequestAddReviewer)
+		r.Post("/removeReviewer", h.PostPullRequestRemoveReviewer)
+		r.Post("/review", h.PostPullRequestReview)
+		r.Post("/scanStale", h.PostPullRequestScanStale)
 	})
 
 	// team routes
 	r.Route("/team", func(r chi.Router) {
 		r.Post("/add", h.PostTeamAdd)
 		r.Get("/get", h.GetTeamGet)
+		r.Post("/setSettings", h.PostTeamSetSettings)
+		r.Post("/addMember", h.PostTeamAddMember)
+		r.Post("/removeMember", h.PostTeamRemoveMember)
+		r.Post("/rename", h.PostTeamRename)
+		r.Post("/setArchived", h.PostTeamSetArchived)
+		r.Post("/delete", h.PostTeamDelete)
+		r.Post("/setParent", h.PostTeamSetParent)
+		r.Get("/subtree", h.GetTeamSubtree)
+		r.Get("/ancestors", h.GetTeamAncestors)
 	})
 
 	// users routes
 	r.Route("/users", func(r chi.Router) {
+		r.Get("/get", h.GetUsersGet)
+		r.Get("/list", h.GetUsersList)
 		r.Get("/getReview", h.GetUsersGetReview)
 		r.Post("/setIsActive", h.PostUsersSetIsActive)
+		r.Post("/setMaxOpenReviews", h.PostUsersSetMaxOpenReviews)
+		r.Post("/setPrimaryTeam", h.PostUsersSetPrimaryTeam)
+		r.Post("/deactivateBatch", h.PostUsersDeactivateBatch)
+
+		r.Route("/unavailability", func(r chi.Router) {
+			r.Post("/add", h.PostUsersUnavailabilityAdd)
+			r.Get("/list", h.GetUsersUnavailabilityList)
+			r.Post("/update", h.PostUsersUnavailabilityUpdate)
+			r.Post("/delete", h.PostUsersUnavailabilityDelete)
+		})
+	})
+
+	// code owners routes
+	r.Route("/codeOwners", func(r chi.Router) {
+		r.Get("/get", h.GetCodeOwnersGet)
+		r.Post("/set", h.PostCodeOwnersSet)
+		r.Post("/import", h.PostCodeOwnersImport)
 	})
 
 	// add healthcheck
@@ -10,7 +10,8 @@ import (
 // Создать команду с участниками (создаёт/обновляет пользователей)
 // (POST /team/add)
 func (h *Handler) PostTeamAdd(w http.ResponseWriter, r *http.Request) {
-	var req domain.PostTeamAddJSONRequestBody
+	// незаданные в запросе настройки берутся по умолчанию, явный 0 в max_reviewers отклоняется при валидации
+	req := domain.PostTeamAddJSONRequestBody{Settings: domain.DefaultTeamSettings()}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		h.sendError(w, http.StatusBadRequest, domain.NOTFOUND, "Invalid request format")
@@ -18,7 +19,7 @@ func (h *Handler) PostTeamAdd(w http.ResponseWriter, r *http.Request) {
 	}
 
 	ctx := r.Context()
-	team, err := h.service.CreateTeam(ctx, req.TeamName, req.Members)
+	team, err := h.service.CreateTeam(ctx, req.TeamName, req.Members, req.Settings, req.ParentTeam)
 	if err != nil {
 		h.handleError(ctx, w, err)
 		return
@@ -41,3 +42,173 @@ func (h *Handler) GetTeamGet(w http.ResponseWriter, r *http.Request) {
 
 	h.respondJSON(w, http.StatusOK, team)
 }
+
+// Обновить настройки назначения ревьюверов команды
+// (POST /team/setSettings)
+func (h *Handler) PostTeamSetSettings(w http.ResponseWriter, r *http.Request) {
+	var req domain.PostTeamSetSettingsJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, domain.NOTFOUND, "Invalid request format")
+		return
+	}
+
+	ctx := r.Context()
+	team, err := h.service.UpdateTeamSettings(ctx, req.TeamName, req.Settings)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, team)
+}
+
+// Добавить участников в существующую команду (создаёт/обновляет пользователей)
+// (POST /team/addMember)
+func (h *Handler) PostTeamAddMember(w http.ResponseWriter, r *http.Request) {
+	var req domain.PostTeamAddMemberJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, domain.NOTFOUND, "Invalid request format")
+		return
+	}
+
+	ctx := r.Context()
+	team, err := h.service.AddTeamMembers(ctx, &req)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, team)
+}
+
+// Исключить участника из команды, его открытые ревью переназначаются
+// (POST /team/removeMember)
+func (h *Handler) PostTeamRemoveMember(w http.ResponseWriter, r *http.Request) {
+	var req domain.PostTeamRemoveMemberJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, domain.NOTFOUND, "Invalid request format")
+		return
+	}
+
+	ctx := r.Context()
+	resp, err := h.service.RemoveTeamMember(ctx, &req)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, resp)
+}
+
+// Переименовать команду
+// (POST /team/rename)
+func (h *Handler) PostTeamRename(w http.ResponseWriter, r *http.Request) {
+	var req domain.PostTeamRenameJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, domain.NOTFOUND, "Invalid request format")
+		return
+	}
+
+	ctx := r.Context()
+	team, err := h.service.RenameTeam(ctx, &req)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, team)
+}
+
+// Архивировать команду или вернуть её из архива
+// (POST /team/setArchived)
+func (h *Handler) PostTeamSetArchived(w http.ResponseWriter, r *http.Request) {
+	var req domain.PostTeamSetArchivedJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, domain.NOTFOUND, "Invalid request format")
+		return
+	}
+
+	ctx := r.Context()
+	team, err := h.service.SetTeamArchived(ctx, &req)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, team)
+}
+
+// Удалить пустую или архивную команду
+// (POST /team/delete)
+func (h *Handler) PostTeamDelete(w http.ResponseWriter, r *http.Request) {
+	var req domain.PostTeamDeleteJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, domain.NOTFOUND, "Invalid request format")
+		return
+	}
+
+	ctx := r.Context()
+	resp, err := h.service.DeleteTeam(ctx, &req)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, resp)
+}
+
+// Перенести команду в другую родительскую команду
+// (POST /team/setParent)
+func (h *Handler) PostTeamSetParent(w http.ResponseWriter, r *http.Request) {
+	var req domain.PostTeamSetParentJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, domain.NOTFOUND, "Invalid request format")
+		return
+	}
+
+	ctx := r.Context()
+	team, err := h.service.SetTeamParent(ctx, &req)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, team)
+}
+
+// Получить дерево вложенных команд
+// (GET /team/subtree)
+func (h *Handler) GetTeamSubtree(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+
+	ctx := r.Context()
+	tree, err := h.service.GetTeamSubtree(ctx, teamName)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, tree)
+}
+
+// Получить цепочку родительских команд
+// (GET /team/ancestors)
+func (h *Handler) GetTeamAncestors(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+
+	ctx := r.Context()
+	resp, err := h.service.GetTeamAncestors(ctx, teamName)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, resp)
+}
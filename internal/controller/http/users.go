@@ -2,11 +2,74 @@ package http
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/Egorrrad/avitotechBackendPR/internal/domain"
 )
 
+// Получить пользователя с его командами и текущей нагрузкой
+// (GET /users/get)
+func (h *Handler) GetUsersGet(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+
+	ctx := r.Context()
+	user, err := h.service.GetUser(ctx, userID)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, user)
+}
+
+// Список пользователей с фильтрами и постраничной выдачей
+// (GET /users/list)
+func (h *Handler) GetUsersList(w http.ResponseWriter, r *http.Request) {
+	params, err := parseUsersListParams(r.URL.Query())
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, domain.BADREQUEST, err.Error())
+		return
+	}
+
+	ctx := r.Context()
+	list, err := h.service.ListUsers(ctx, params)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, list)
+}
+
+func parseUsersListParams(query url.Values) (domain.GetUsersListParams, error) {
+	params := domain.GetUsersListParams{
+		TeamName:       query.Get("team_name"),
+		UsernamePrefix: query.Get("username"),
+		Cursor:         query.Get("cursor"),
+	}
+
+	if v := query.Get("is_active"); v != "" {
+		isActive, err := strconv.ParseBool(v)
+		if err != nil {
+			return params, fmt.Errorf("is_active must be true or false")
+		}
+		params.IsActive = &isActive
+	}
+
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return params, fmt.Errorf("limit must be an integer")
+		}
+		params.Limit = limit
+	}
+
+	return params, nil
+}
+
 // Получить PR'ы, где пользователь назначен ревьювером
 // (GET /users/getReview)
 func (h *Handler) GetUsersGetReview(w http.ResponseWriter, r *http.Request) {
@@ -33,7 +96,7 @@ func (h *Handler) PostUsersSetIsActive(w http.ResponseWriter, r *http.Request) {
 	}
 
 	ctx := r.Context()
-	updUser, err := h.service.UpdateUserActive(ctx, req.UserID, req.IsActive)
+	updUser, err := h.service.UpdateUserActive(ctx, &req)
 	if err != nil {
 		h.handleError(ctx, w, err)
 		return
@@ -41,3 +104,63 @@ func (h *Handler) PostUsersSetIsActive(w http.ResponseWriter, r *http.Request) {
 
 	h.respondJSON(w, http.StatusOK, updUser)
 }
+
+// Установить лимит одновременных открытых ревью пользователя
+// (POST /users/setMaxOpenReviews)
+func (h *Handler) PostUsersSetMaxOpenReviews(w http.ResponseWriter, r *http.Request) {
+	var req domain.PostUsersSetMaxOpenReviewsJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, domain.NOTFOUND, "Invalid request format")
+		return
+	}
+
+	ctx := r.Context()
+	resp, err := h.service.SetMaxOpenReviews(ctx, &req)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, resp)
+}
+
+// Сделать одну из команд пользователя основной
+// (POST /users/setPrimaryTeam)
+func (h *Handler) PostUsersSetPrimaryTeam(w http.ResponseWriter, r *http.Request) {
+	var req domain.PostUsersSetPrimaryTeamJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, domain.NOTFOUND, "Invalid request format")
+		return
+	}
+
+	ctx := r.Context()
+	resp, err := h.service.SetPrimaryTeam(ctx, &req)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, resp)
+}
+
+// Деактивировать команду или список пользователей с передачей их открытых ревью
+// (POST /users/deactivateBatch)
+func (h *Handler) PostUsersDeactivateBatch(w http.ResponseWriter, r *http.Request) {
+	var req domain.PostUsersDeactivateBatchJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, domain.NOTFOUND, "Invalid request format")
+		return
+	}
+
+	ctx := r.Context()
+	resp, err := h.service.DeactivateUsers(ctx, &req)
+	if err != nil {
+		h.handleError(ctx, w, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, resp)
+}
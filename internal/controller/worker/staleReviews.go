@@ -0,0 +1,96 @@
+// Package worker implements background jobs driving the use cases.
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Egorrrad/avitotechBackendPR/internal/domain"
+	"github.com/Egorrrad/avitotechBackendPR/pkg/logger"
+)
+
+type StaleReviewService interface {
+	HandleStaleReviews(ctx context.Context, before time.Time, dryRun bool) ([]domain.StaleReview, error)
+}
+
+// StaleReviews periodically handles reviews not acted on within the SLA.
+type StaleReviews struct {
+	service StaleReviewService
+	l       logger.Interface
+
+	sla      time.Duration
+	interval time.Duration
+	dryRun   bool
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewStaleReviews -.
+func NewStaleReviews(service StaleReviewService, l logger.Interface, sla, interval time.Duration,
+	dryRun bool) *StaleReviews {
+	return &StaleReviews{
+		service:  service,
+		l:        l,
+		sla:      sla,
+		interval: interval,
+		dryRun:   dryRun,
+	}
+}
+
+// Start runs the first scan right away and then every interval until Stop.
+func (w *StaleReviews) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			w.scan(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	w.l.Info("worker - StaleReviews - Started", "sla", w.sla.String(), "interval", w.interval.String(),
+		"dry_run", w.dryRun)
+}
+
+// Stop cancels the running scan and waits for it to finish.
+func (w *StaleReviews) Stop() {
+	if w.cancel == nil {
+		return
+	}
+	w.cancel()
+	w.wg.Wait()
+
+	w.l.Info("worker - StaleReviews - Stopped")
+}
+
+func (w *StaleReviews) scan(ctx context.Context) {
+	reviews, err := w.service.HandleStaleReviews(ctx, time.Now().Add(-w.sla), w.dryRun)
+	if err != nil && ctx.Err() == nil {
+		w.l.Error("worker - StaleReviews - HandleStaleReviews", "error", err)
+	}
+
+	for _, r := range reviews {
+		w.l.Warn("worker - StaleReviews - stale review",
+			"pull_request_id", r.PullRequestID,
+			"reviewer_id", r.ReviewerID,
+			"last_activity_at", r.LastActivityAt,
+			"action", string(r.Action),
+			"target", r.Target,
+			"dry_run", w.dryRun,
+		)
+	}
+}
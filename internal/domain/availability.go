@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// Unavailability defines model for Unavailability.
+// User is excluded from assignment while StartsAt <= now < EndsAt.
+type Unavailability struct {
+	EndsAt   time.Time `json:"ends_at"`
+	ID       int64     `json:"id"`
+	Reason   string    `json:"reason"`
+	StartsAt time.Time `json:"starts_at"`
+	UserID   string    `json:"user_id"`
+}
+
+// PostUsersUnavailabilityAddJSONBody defines parameters for PostUsersUnavailabilityAdd.
+type PostUsersUnavailabilityAddJSONBody struct {
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason"`
+	StartsAt time.Time `json:"starts_at"`
+	UserID   string    `json:"user_id"`
+}
+
+// PostUsersUnavailabilityUpdateJSONBody defines parameters for PostUsersUnavailabilityUpdate.
+type PostUsersUnavailabilityUpdateJSONBody struct {
+	EndsAt   time.Time `json:"ends_at"`
+	ID       int64     `json:"id"`
+	Reason   string    `json:"reason"`
+	StartsAt time.Time `json:"starts_at"`
+}
+
+// PostUsersUnavailabilityDeleteJSONBody defines parameters for PostUsersUnavailabilityDelete.
+type PostUsersUnavailabilityDeleteJSONBody struct {
+	ID int64 `json:"id"`
+}
+
+type UnavailabilityResponse struct {
+	Unavailability Unavailability `json:"unavailability"`
+}
+
+type UserUnavailabilityResponse struct {
+	UserID         string           `json:"user_id"`
+	Unavailability []Unavailability `json:"unavailability"`
+}
@@ -0,0 +1,37 @@
+package domain
+
+const (
+	CodeOwnersModePreferred CodeOwnersMode = "PREFERRED"
+	CodeOwnersModeRequired  CodeOwnersMode = "REQUIRED"
+)
+
+// CodeOwnersMode defines how code owners of changed paths are treated on assignment.
+type CodeOwnersMode string
+
+// IsValid reports whether mode is one of the known values.
+func (m CodeOwnersMode) IsValid() bool {
+	return m == CodeOwnersModePreferred || m == CodeOwnersModeRequired
+}
+
+// CodeOwnerRule defines model for CodeOwnerRule.
+// Later rules take precedence over earlier ones, as in CODEOWNERS file.
+type CodeOwnerRule struct {
+	Pattern string   `json:"pattern"`
+	Teams   []string `json:"teams"`
+	Users   []string `json:"users"`
+}
+
+// PostCodeOwnersSetJSONBody defines parameters for PostCodeOwnersSet.
+type PostCodeOwnersSetJSONBody struct {
+	Rules []CodeOwnerRule `json:"rules"`
+}
+
+// PostCodeOwnersImportJSONBody defines parameters for PostCodeOwnersImport.
+type PostCodeOwnersImportJSONBody struct {
+	// Content CODEOWNERS file content
+	Content string `json:"content"`
+}
+
+type CodeOwnersResponse struct {
+	Rules []CodeOwnerRule `json:"rules"`
+}
@@ -12,7 +12,20 @@ const (
 	TEAMEXISTS  ErrorResponseErrorCode = "TEAM_EXISTS"
 
 	// add new statuses
-	INTERNAL ErrorResponseErrorCode = "INTERNAL_ERROR"
+	INTERNAL    ErrorResponseErrorCode = "INTERNAL_ERROR"
+	BADREQUEST  ErrorResponseErrorCode = "BAD_REQUEST"
+	NOTENOUGH   ErrorResponseErrorCode = "NOT_ENOUGH_REVIEWERS"
+	NOOWNER     ErrorResponseErrorCode = "NO_CODE_OWNER"
+	ATCAPACITY  ErrorResponseErrorCode = "REVIEWERS_AT_CAPACITY"
+	TRANSITION  ErrorResponseErrorCode = "INVALID_STATUS_TRANSITION"
+	BLOCKED     ErrorResponseErrorCode = "MERGE_BLOCKED"
+	ASSIGNED    ErrorResponseErrorCode = "ALREADY_ASSIGNED"
+	LIMIT       ErrorResponseErrorCode = "REVIEWER_LIMIT"
+	REASSIGNS   ErrorResponseErrorCode = "REASSIGN_LIMIT"
+	NOTEMPTY    ErrorResponseErrorCode = "TEAM_NOT_EMPTY"
+	FORBIDDEN   ErrorResponseErrorCode = "FORBIDDEN"
+	UNAVAILABLE ErrorResponseErrorCode = "REVIEWER_UNAVAILABLE"
+	REVIEWABLE  ErrorResponseErrorCode = "PR_NOT_REVIEWABLE"
 )
 
 // ErrorResponse defines model for ErrorResponse.
@@ -24,6 +37,8 @@ type ErrorResponse struct {
 type ErrorDetails struct {
 	Code    ErrorResponseErrorCode `json:"code"`
 	Message string                 `json:"message"`
+	// Details optional list of specific problems, e.g. unmet merge conditions
+	Details []string `json:"details,omitempty"`
 }
 
 // ErrorResponseErrorCode defines model for ErrorResponse.Error.Code.
@@ -39,4 +54,55 @@ var (
 	ErrNoCandidatesFound   = errors.New("no candidates found")
 	ErrUserNotReviewer     = errors.New("user not reviewer")
 	ErrChangeAfterMerge    = errors.New("cannot change after merge PR")
+
+	ErrInvalidReviewerStrategy = errors.New("invalid reviewer strategy")
+	ErrInvalidTeamSettings     = errors.New("invalid team settings")
+	ErrNotEnoughReviewers      = errors.New("not enough reviewers")
+	ErrCodeOwnersUnavailable   = errors.New("no available code owner")
+	ErrInvalidCodeOwners       = errors.New("invalid code owners rules")
+
+	ErrUnavailabilityNotFound = errors.New("unavailability period not found")
+	ErrInvalidUnavailability  = errors.New("invalid unavailability period")
+
+	ErrEmptyUserSet = errors.New("no users to deactivate")
+
+	ErrInvalidReviewCapacity = errors.New("max_open_reviews must not be negative")
+	ErrReviewersAtCapacity   = errors.New("all candidates are at review capacity")
+
+	ErrInvalidReviewDecision = errors.New("invalid review decision")
+	ErrInvalidTransition     = errors.New("invalid pull request status transition")
+	ErrNotReviewable         = errors.New("pull request is not open for review")
+	ErrMergeBlocked          = errors.New("merge blocked by merge policy")
+	ErrForceActorRequired    = errors.New("actor is required for forced merge")
+	ErrForbidden             = errors.New("actor is not allowed to perform this action")
+	ErrInvalidListParams     = errors.New("invalid list parameters")
+
+	ErrAuthorAsReviewer     = errors.New("author cannot review own pull request")
+	ErrAlreadyReviewer      = errors.New("user already assigned as reviewer")
+	ErrReviewerUnavailable  = errors.New("user is inactive or unavailable")
+	ErrReviewerLimitReached = errors.New("pull request already has max reviewers")
+	ErrReassignLimitReached = errors.New("pull request reached max reassignments")
+	ErrReservedActor        = errors.New("actor system is reserved")
+	ErrInvalidPriority      = errors.New("invalid pull request priority")
+
+	ErrNotTeamMember = errors.New("user is not a member of the team")
+	ErrTeamNotEmpty  = errors.New("team has members and is not archived")
+	ErrEmptyTeamName = errors.New("team name must not be empty")
+	ErrTeamCycle     = errors.New("team cannot be its own ancestor")
+	ErrInvalidRole   = errors.New("invalid team member role")
+
+	ErrStaleScanBefore = errors.New("before is required")
 )
+
+// MergeBlockedError lists merge policy conditions a pull request does not meet.
+type MergeBlockedError struct {
+	Missing []string
+}
+
+func (e *MergeBlockedError) Error() string {
+	return ErrMergeBlocked.Error()
+}
+
+func (e *MergeBlockedError) Is(target error) bool {
+	return target == ErrMergeBlocked
+}
@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+const (
+	PREventCreated            PREventType = "CREATED"
+	PREventReviewerAssigned   PREventType = "REVIEWER_ASSIGNED"
+	PREventReviewerReassigned PREventType = "REVIEWER_REASSIGNED"
+	PREventReviewerRemoved    PREventType = "REVIEWER_REMOVED"
+	PREventStatusChanged      PREventType = "STATUS_CHANGED"
+	PREventMerged             PREventType = "MERGED"
+	PREventReviewSubmitted    PREventType = "REVIEW_SUBMITTED"
+	PREventReviewStale        PREventType = "REVIEW_STALE"
+)
+
+// ActorSystem marks changes made by the service itself, e.g. on user deactivation.
+const ActorSystem = "system"
+
+// PREventType defines kind of pull request history event.
+type PREventType string
+
+// PREvent defines one entry of pull request history. Fields not relevant to the type are empty.
+type PREvent struct {
+	CreatedAt  time.Time         `json:"created_at"`
+	Type       PREventType       `json:"type"`
+	Actor      string            `json:"actor,omitempty"`
+	ReviewerID string            `json:"reviewer_id,omitempty"`
+	ReplacedBy string            `json:"replaced_by,omitempty"`
+	FromStatus PullRequestStatus `json:"from_status,omitempty"`
+	ToStatus   PullRequestStatus `json:"to_status,omitempty"`
+	Details    string            `json:"details,omitempty"`
+	// Seed of the assignment that picked the reviewer, replays it exactly
+	Seed *int64 `json:"seed,omitempty"`
+}
+
+// PullRequestHistoryResponse defines history of a pull request, oldest event first.
+type PullRequestHistoryResponse struct {
+	PullRequestID string    `json:"pull_request_id"`
+	Events        []PREvent `json:"events"`
+}
@@ -7,54 +7,269 @@ import (
 const (
 	PullRequestStatusMERGED PullRequestStatus = "MERGED"
 	PullRequestStatusOPEN   PullRequestStatus = "OPEN"
+	PullRequestStatusDRAFT  PullRequestStatus = "DRAFT"
+	PullRequestStatusCLOSED PullRequestStatus = "CLOSED"
 )
 
+const (
+	ReviewDecisionApproved         ReviewDecision = "APPROVED"
+	ReviewDecisionChangesRequested ReviewDecision = "CHANGES_REQUESTED"
+	ReviewDecisionCommented        ReviewDecision = "COMMENTED"
+)
+
+const (
+	PriorityLow      PullRequestPriority = "LOW"
+	PriorityNormal   PullRequestPriority = "NORMAL"
+	PriorityHigh     PullRequestPriority = "HIGH"
+	PriorityCritical PullRequestPriority = "CRITICAL"
+)
+
+// PullRequestPriority defines urgency of a pull request.
+type PullRequestPriority string
+
+// IsValid reports whether priority is one of the known values.
+func (p PullRequestPriority) IsValid() bool {
+	switch p {
+	case PriorityLow, PriorityNormal, PriorityHigh, PriorityCritical:
+		return true
+	}
+	return false
+}
+
+// IsUrgent reports whether reviewers are picked by the lowest load and team extra reviewers apply.
+func (p PullRequestPriority) IsUrgent() bool {
+	return p == PriorityHigh || p == PriorityCritical
+}
+
+// ReviewDecision defines verdict a reviewer submits for a pull request.
+type ReviewDecision string
+
+func (d ReviewDecision) IsValid() bool {
+	switch d {
+	case ReviewDecisionApproved, ReviewDecisionChangesRequested, ReviewDecisionCommented:
+		return true
+	}
+	return false
+}
+
+// ReviewerDecision defines the latest decision of a reviewer.
+type ReviewerDecision struct {
+	CreatedAt time.Time      `json:"created_at"`
+	Decision  ReviewDecision `json:"decision"`
+	UserID    string         `json:"user_id"`
+}
+
 // PostPullRequestCreateJSONBody defines parameters for PostPullRequestCreate.
 type PostPullRequestCreateJSONBody struct {
-	AuthorID        string `json:"author_id"`
-	PullRequestID   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
+	AuthorID     string   `json:"author_id"`
+	ChangedPaths []string `json:"changed_paths"`
+	// Draft creates PR in DRAFT status without reviewers
+	Draft  bool     `json:"draft,omitempty"`
+	Labels []string `json:"labels,omitempty"`
+	// Priority empty means NORMAL
+	Priority        PullRequestPriority `json:"priority,omitempty"`
+	PullRequestID   string              `json:"pull_request_id"`
+	PullRequestName string              `json:"pull_request_name"`
+	// TeamName one of the author's teams whose members review the PR, empty means the author's primary team
+	TeamName string `json:"team_name,omitempty"`
 }
 
 // PostPullRequestMergeJSONBody defines parameters for PostPullRequestMerge.
 type PostPullRequestMergeJSONBody struct {
+	// Force bypasses merge policy, such merges are recorded for audit
+	Force bool `json:"force,omitempty"`
+	// Actor who forces the merge, required with Force
+	Actor         string `json:"actor,omitempty"`
+	PullRequestID string `json:"pull_request_id"`
+}
+
+// PostPullRequestReadyJSONBody defines parameters for PostPullRequestReady.
+type PostPullRequestReadyJSONBody struct {
+	PullRequestID string `json:"pull_request_id"`
+}
+
+// PostPullRequestCloseJSONBody defines parameters for PostPullRequestClose.
+type PostPullRequestCloseJSONBody struct {
+	PullRequestID string `json:"pull_request_id"`
+}
+
+// PostPullRequestReopenJSONBody defines parameters for PostPullRequestReopen.
+type PostPullRequestReopenJSONBody struct {
+	PullRequestID string `json:"pull_request_id"`
+}
+
+// PostPullRequestAddReviewerJSONBody defines parameters for PostPullRequestAddReviewer.
+type PostPullRequestAddReviewerJSONBody struct {
+	// Actor who added the reviewer, recorded in PR history. Required to be an admin, a maintainer
+	// or the lead of the PR team when reviewer edits are restricted
+	Actor         string `json:"actor,omitempty"`
 	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id"`
+}
+
+// PostPullRequestRemoveReviewerJSONBody defines parameters for PostPullRequestRemoveReviewer.
+type PostPullRequestRemoveReviewerJSONBody struct {
+	// Actor who removed the reviewer, recorded in PR history. Required to be an admin, a maintainer
+	// or the lead of the PR team when reviewer edits are restricted
+	Actor         string `json:"actor,omitempty"`
+	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id"`
+}
+
+// PostPullRequestReviewJSONBody defines parameters for PostPullRequestReview.
+type PostPullRequestReviewJSONBody struct {
+	Decision      ReviewDecision `json:"decision"`
+	PullRequestID string         `json:"pull_request_id"`
+	ReviewerID    string         `json:"reviewer_id"`
 }
 
 // PostPullRequestReassignJSONBody defines parameters for PostPullRequestReassign.
 type PostPullRequestReassignJSONBody struct {
+	// Actor who requested the reassignment, recorded in PR history
+	Actor         string `json:"actor,omitempty"`
 	OldUserID     string `json:"old_user_id"`
 	PullRequestID string `json:"pull_request_id"`
 }
 
 type PullRequestStatus string
 
+// IsValid reports whether status is one of the known values.
+func (s PullRequestStatus) IsValid() bool {
+	switch s {
+	case PullRequestStatusDRAFT, PullRequestStatusOPEN, PullRequestStatusMERGED, PullRequestStatusCLOSED:
+		return true
+	}
+	return false
+}
+
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 100
+)
+
+const (
+	PullRequestSortCreatedAt PullRequestSortField = "created_at"
+	PullRequestSortName      PullRequestSortField = "name"
+)
+
+// PullRequestSortField defines field pull requests are listed by.
+type PullRequestSortField string
+
+// GetPullRequestListParams defines parameters for GetPullRequestList.
+// Empty filters are not applied, time ranges are [from, to).
+type GetPullRequestListParams struct {
+	Status      PullRequestStatus
+	AuthorID    string
+	ReviewerID  string
+	TeamName    string
+	Name        string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	MergedFrom  *time.Time
+	MergedTo    *time.Time
+	SortBy      PullRequestSortField
+	Desc        bool
+	Limit       int
+	// Cursor opaque position returned as next_cursor by the previous page
+	Cursor string
+}
+
+// PullRequestListResponse defines a page of pull requests.
+type PullRequestListResponse struct {
+	PullRequests []*PullRequest `json:"pull_requests"`
+	// NextCursor empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
 // PullRequest defines model for PullRequest.
 type PullRequest struct {
-	// AssignedReviewers user_id назначенных ревьюверов (0..2)
-	AssignedReviewers []string          `json:"assigned_reviewers"`
-	AuthorID          string            `json:"author_id"`
-	CreatedAt         *time.Time        `json:"createdAt"`
-	MergedAt          *time.Time        `json:"mergedAt"`
-	PullRequestID     string            `json:"pull_request_id"`
-	PullRequestName   string            `json:"pull_request_name"`
-	Status            PullRequestStatus `json:"status"`
+	// AssignedReviewers user_id назначенных ревьюверов (0..max_reviewers команды)
+	AssignedReviewers []string `json:"assigned_reviewers"`
+	// AssignmentSeed seed of the last reviewer assignment, replays it exactly
+	AssignmentSeed  *int64              `json:"assignment_seed,omitempty"`
+	AuthorID        string              `json:"author_id"`
+	ChangedPaths    []string            `json:"changed_paths,omitempty"`
+	CreatedAt       *time.Time          `json:"createdAt"`
+	Labels          []string            `json:"labels"`
+	MergedAt        *time.Time          `json:"mergedAt"`
+	Priority        PullRequestPriority `json:"priority"`
+	PullRequestID   string              `json:"pull_request_id"`
+	PullRequestName string              `json:"pull_request_name"`
+	// ReleasedReviewers reviewers removed on close, restored on reopen
+	ReleasedReviewers []string `json:"-"`
+	// RemovedReviewers former reviewers removed or reassigned explicitly, never picked again automatically;
+	// ids added to it are recorded as removed on update, reviewers released on close are not
+	RemovedReviewers []string `json:"-"`
+	// Reviewers details of AssignedReviewers, filled only by /pullRequest/get
+	Reviewers []ReviewerInfo `json:"reviewers,omitempty"`
+	// ReviewDecisions latest decision of every assigned reviewer who submitted one
+	ReviewDecisions []ReviewerDecision `json:"review_decisions,omitempty"`
+	Status          PullRequestStatus  `json:"status"`
+	// TeamName team whose members review the PR, empty if the team was deleted
+	TeamName string `json:"team_name,omitempty"`
+}
+
+// ReviewerInfo defines assigned reviewer with their username and team.
+type ReviewerInfo struct {
+	TeamName string `json:"team_name"`
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
 }
 
 // PullRequestShort defines model for PullRequestShort.
 type PullRequestShort struct {
-	AuthorID        string            `json:"author_id"`
-	PullRequestID   string            `json:"pull_request_id"`
-	PullRequestName string            `json:"pull_request_name"`
-	Status          PullRequestStatus `json:"status"`
+	AuthorID        string              `json:"author_id"`
+	Labels          []string            `json:"labels"`
+	Priority        PullRequestPriority `json:"priority"`
+	PullRequestID   string              `json:"pull_request_id"`
+	PullRequestName string              `json:"pull_request_name"`
+	Status          PullRequestStatus   `json:"status"`
+}
+
+// StaleReview defines reviewer who has not acted on an OPEN pull request within the SLA.
+// Assignment, submitted review and a previous stale flag count as activity.
+type StaleReview struct {
+	PullRequestID  string    `json:"pull_request_id"`
+	ReviewerID     string    `json:"reviewer_id"`
+	LastActivityAt time.Time `json:"last_activity_at"`
+	// Action applied by the author's team policy, in dry run the one that would be applied
+	Action StaleReviewAction `json:"action"`
+	// Target new reviewer on reassignment or lead on escalation, empty if nobody was available
+	Target string `json:"target,omitempty"`
+}
+
+// PostPullRequestScanStaleJSONBody defines parameters for PostPullRequestScanStale.
+type PostPullRequestScanStaleJSONBody struct {
+	// Before reviews without activity since this moment are stale
+	Before time.Time `json:"before"`
+	// DryRun returns actions that would be applied without writing anything
+	DryRun bool `json:"dry_run,omitempty"`
+	// PullRequestID empty means all OPEN pull requests
+	PullRequestID string `json:"pull_request_id,omitempty"`
+}
+
+type StaleReviewsResponse struct {
+	StaleReviews []StaleReview `json:"stale_reviews"`
+}
+
+// FallbackReviewer defines reviewer taken from a fallback team.
+type FallbackReviewer struct {
+	TeamName string `json:"team_name"`
+	UserID   string `json:"user_id"`
 }
 
 // for responses
 type PullRequestResponse struct {
-	PR PullRequest `json:"pr"`
+	PR                PullRequest        `json:"pr"`
+	FallbackReviewers []FallbackReviewer `json:"fallback_reviewers,omitempty"`
+	// MaintainerMissing the team requires a maintainer among reviewers but none was available
+	MaintainerMissing bool `json:"maintainer_missing,omitempty"`
 }
 
 type ReassignPRResponse struct {
-	PR         PullRequest `json:"pr"`
-	ReplacedBy string      `json:"replaced_by,omitempty"`
+	PR                PullRequest        `json:"pr"`
+	ReplacedBy        string             `json:"replaced_by,omitempty"`
+	FallbackReviewers []FallbackReviewer `json:"fallback_reviewers,omitempty"`
+	// MaintainerMissing reviewers are left without a maintainer required by the team, none was available
+	MaintainerMissing bool `json:"maintainer_missing,omitempty"`
 }
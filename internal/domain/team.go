@@ -1,17 +1,332 @@
 package domain
 
+const (
+	DefaultMinReviewers = 0
+	DefaultMaxReviewers = 2
+)
+
+const (
+	ReviewerStrategyRandom         ReviewerStrategy = "RANDOM"
+	ReviewerStrategyRoundRobin     ReviewerStrategy = "ROUND_ROBIN"
+	ReviewerStrategyLeastLoaded    ReviewerStrategy = "LEAST_LOADED"
+	ReviewerStrategyWeightedRandom ReviewerStrategy = "WEIGHTED_RANDOM"
+)
+
+// ReviewerStrategy defines algorithm used to pick reviewers from candidates.
+type ReviewerStrategy string
+
+// IsValid reports whether strategy is one of the known values.
+func (s ReviewerStrategy) IsValid() bool {
+	switch s {
+	case ReviewerStrategyRandom, ReviewerStrategyRoundRobin,
+		ReviewerStrategyLeastLoaded, ReviewerStrategyWeightedRandom:
+		return true
+	}
+	return false
+}
+
 // Team defines model for Team.
 type Team struct {
-	Members  []TeamMember `json:"members"`
-	TeamName string       `json:"team_name"`
+	Members []TeamMember `json:"members"`
+	// ParentTeam team this one belongs to, empty for a top-level team
+	ParentTeam string       `json:"parent_team,omitempty"`
+	TeamName   string       `json:"team_name"`
+	Settings   TeamSettings `json:"settings"`
+	// Archived team is no longer in use and may be deleted with its members
+	Archived bool `json:"archived"`
+}
+
+const (
+	TeamRoleMember     TeamRole = "MEMBER"
+	TeamRoleMaintainer TeamRole = "MAINTAINER"
+	TeamRoleLead       TeamRole = "LEAD"
+)
+
+// TeamRole defines role of a member in the team.
+type TeamRole string
+
+// IsValid reports whether role is one of the known values.
+func (r TeamRole) IsValid() bool {
+	switch r {
+	case TeamRoleMember, TeamRoleMaintainer, TeamRoleLead:
+		return true
+	}
+	return false
 }
 
 // TeamMember defines model for TeamMember.
 type TeamMember struct {
-	IsActive bool   `json:"is_active"`
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
+	IsActive bool `json:"is_active"`
+	// MaxOpenReviews limit of concurrent OPEN reviews, nil means unlimited
+	MaxOpenReviews *int `json:"max_open_reviews,omitempty"`
+	// Role in this team, empty means MEMBER
+	Role     TeamRole `json:"role"`
+	UserID   string   `json:"user_id"`
+	Username string   `json:"username"`
+}
+
+// TeamSettings defines per-team assignment settings.
+type TeamSettings struct {
+	// ReviewerStrategy overrides global strategy, empty means default
+	ReviewerStrategy ReviewerStrategy `json:"reviewer_strategy,omitempty"`
+	// MinReviewers PR creation fails if fewer reviewers can be assigned
+	MinReviewers int `json:"min_reviewers"`
+	// MaxReviewers number of reviewers assigned on PR creation, must be at least 1
+	MaxReviewers int `json:"max_reviewers"`
+	// FallbackTeams ordered teams used when own team has too few candidates
+	FallbackTeams []string `json:"fallback_teams"`
+	// CodeOwnersMode whether code owners of changed paths are preferred or required
+	CodeOwnersMode CodeOwnersMode `json:"code_owners_mode"`
+	// MergePolicy conditions checked before PR of the team's author is merged
+	MergePolicy MergePolicy `json:"merge_policy"`
+	// StaleReview what happens to reviews of the team's PRs not acted on within the SLA
+	StaleReview StaleReviewPolicy `json:"stale_review"`
+	// PriorityRules extra reviewers for HIGH and CRITICAL PRs of the team's authors
+	PriorityRules PriorityRules `json:"priority_rules"`
+	// RequireMaintainer one of reviewers is a maintainer or the lead of the team while any is available
+	RequireMaintainer bool `json:"require_maintainer"`
+}
+
+// PriorityRules defines how many reviewers are added on top of max_reviewers for urgent pull requests.
+type PriorityRules struct {
+	ExtraReviewersHigh     int `json:"extra_reviewers_high"`
+	ExtraReviewersCritical int `json:"extra_reviewers_critical"`
+}
+
+// ReviewerLimit returns the number of reviewers a pull request with the priority gets.
+func (s *TeamSettings) ReviewerLimit(priority PullRequestPriority) int {
+	switch priority {
+	case PriorityHigh:
+		return s.MaxReviewers + s.PriorityRules.ExtraReviewersHigh
+	case PriorityCritical:
+		return s.MaxReviewers + s.PriorityRules.ExtraReviewersCritical
+	}
+	return s.MaxReviewers
+}
+
+// MergePolicy defines what a pull request needs to be merged.
+type MergePolicy struct {
+	// RequiredApprovals number of assigned reviewers whose latest decision is APPROVED
+	RequiredApprovals int `json:"required_approvals"`
+	// BlockChangesRequested merge is blocked while any reviewer's latest decision is CHANGES_REQUESTED
+	BlockChangesRequested bool `json:"block_changes_requested"`
+}
+
+const (
+	StaleReviewActionNone     StaleReviewAction = "NONE"
+	StaleReviewActionReassign StaleReviewAction = "REASSIGN"
+	StaleReviewActionEscalate StaleReviewAction = "ESCALATE"
+)
+
+// StaleReviewAction defines how a stale review is handled, every stale review is flagged in PR history.
+type StaleReviewAction string
+
+// IsValid reports whether action is one of the known values.
+func (a StaleReviewAction) IsValid() bool {
+	switch a {
+	case StaleReviewActionNone, StaleReviewActionReassign, StaleReviewActionEscalate:
+		return true
+	}
+	return false
+}
+
+// StaleReviewPolicy defines handling of reviews not acted on within the SLA.
+// ESCALATE assigns the team's LEAD as an extra reviewer.
+type StaleReviewPolicy struct {
+	Action StaleReviewAction `json:"action"`
+}
+
+// DefaultTeamSettings returns settings used when team does not override them.
+func DefaultTeamSettings() TeamSettings {
+	return TeamSettings{
+		MinReviewers:   DefaultMinReviewers,
+		MaxReviewers:   DefaultMaxReviewers,
+		FallbackTeams:  []string{},
+		CodeOwnersMode: CodeOwnersModePreferred,
+		StaleReview:    StaleReviewPolicy{Action: StaleReviewActionNone},
+	}
+}
+
+// Normalize fills unset values with defaults.
+func (s *TeamSettings) Normalize() {
+	if s.FallbackTeams == nil {
+		s.FallbackTeams = []string{}
+	}
+	if s.CodeOwnersMode == "" {
+		s.CodeOwnersMode = CodeOwnersModePreferred
+	}
+	if s.StaleReview.Action == "" {
+		s.StaleReview.Action = StaleReviewActionNone
+	}
+}
+
+// Validate checks settings consistency.
+func (s *TeamSettings) Validate() error {
+	if s.ReviewerStrategy != "" && !s.ReviewerStrategy.IsValid() {
+		return ErrInvalidReviewerStrategy
+	}
+	if s.MinReviewers < 0 || s.MaxReviewers < 1 || s.MinReviewers > s.MaxReviewers {
+		return ErrInvalidTeamSettings
+	}
+	if !s.CodeOwnersMode.IsValid() {
+		return ErrInvalidTeamSettings
+	}
+	if s.MergePolicy.RequiredApprovals < 0 {
+		return ErrInvalidTeamSettings
+	}
+	if s.PriorityRules.ExtraReviewersHigh < 0 || s.PriorityRules.ExtraReviewersCritical < 0 {
+		return ErrInvalidTeamSettings
+	}
+	if !s.StaleReview.Action.IsValid() {
+		return ErrInvalidTeamSettings
+	}
+
+	seen := make(map[string]bool, len(s.FallbackTeams))
+	for _, team := range s.FallbackTeams {
+		if team == "" || seen[team] {
+			return ErrInvalidTeamSettings
+		}
+		seen[team] = true
+	}
+	return nil
 }
 
 // PostTeamAddJSONRequestBody defines body for PostTeamAdd for application/json ContentType.
 type PostTeamAddJSONRequestBody = Team
+
+// TeamSettingsPatch defines a partial settings update, nil fields keep the current values.
+type TeamSettingsPatch struct {
+	ReviewerStrategy *ReviewerStrategy `json:"reviewer_strategy,omitempty"`
+	MinReviewers     *int              `json:"min_reviewers,omitempty"`
+	MaxReviewers     *int              `json:"max_reviewers,omitempty"`
+	// FallbackTeams replaces the whole list when sent, an empty list removes fallbacks
+	FallbackTeams     *[]string           `json:"fallback_teams,omitempty"`
+	CodeOwnersMode    *CodeOwnersMode     `json:"code_owners_mode,omitempty"`
+	MergePolicy       *MergePolicyPatch   `json:"merge_policy,omitempty"`
+	StaleReview       *StaleReviewPolicy  `json:"stale_review,omitempty"`
+	PriorityRules     *PriorityRulesPatch `json:"priority_rules,omitempty"`
+	RequireMaintainer *bool               `json:"require_maintainer,omitempty"`
+}
+
+// MergePolicyPatch defines a partial merge policy update.
+type MergePolicyPatch struct {
+	RequiredApprovals     *int  `json:"required_approvals,omitempty"`
+	BlockChangesRequested *bool `json:"block_changes_requested,omitempty"`
+}
+
+// PriorityRulesPatch defines a partial priority rules update.
+type PriorityRulesPatch struct {
+	ExtraReviewersHigh     *int `json:"extra_reviewers_high,omitempty"`
+	ExtraReviewersCritical *int `json:"extra_reviewers_critical,omitempty"`
+}
+
+// Apply overlays the fields present in the patch on settings.
+func (p *TeamSettingsPatch) Apply(s *TeamSettings) {
+	if p.ReviewerStrategy != nil {
+		s.ReviewerStrategy = *p.ReviewerStrategy
+	}
+	if p.MinReviewers != nil {
+		s.MinReviewers = *p.MinReviewers
+	}
+	if p.MaxReviewers != nil {
+		s.MaxReviewers = *p.MaxReviewers
+	}
+	if p.FallbackTeams != nil {
+		s.FallbackTeams = *p.FallbackTeams
+	}
+	if p.CodeOwnersMode != nil {
+		s.CodeOwnersMode = *p.CodeOwnersMode
+	}
+	if p.MergePolicy != nil {
+		if p.MergePolicy.RequiredApprovals != nil {
+			s.MergePolicy.RequiredApprovals = *p.MergePolicy.RequiredApprovals
+		}
+		if p.MergePolicy.BlockChangesRequested != nil {
+			s.MergePolicy.BlockChangesRequested = *p.MergePolicy.BlockChangesRequested
+		}
+	}
+	if p.StaleReview != nil {
+		s.StaleReview = *p.StaleReview
+	}
+	if p.PriorityRules != nil {
+		if p.PriorityRules.ExtraReviewersHigh != nil {
+			s.PriorityRules.ExtraReviewersHigh = *p.PriorityRules.ExtraReviewersHigh
+		}
+		if p.PriorityRules.ExtraReviewersCritical != nil {
+			s.PriorityRules.ExtraReviewersCritical = *p.PriorityRules.ExtraReviewersCritical
+		}
+	}
+	if p.RequireMaintainer != nil {
+		s.RequireMaintainer = *p.RequireMaintainer
+	}
+}
+
+// PostTeamSetSettingsJSONBody defines parameters for PostTeamSetSettings.
+// Only settings present in the body are changed.
+type PostTeamSetSettingsJSONBody struct {
+	Settings TeamSettingsPatch `json:"settings"`
+	TeamName string            `json:"team_name"`
+}
+
+// PostTeamAddMemberJSONBody defines parameters for PostTeamAddMember.
+// Users are created or updated, roles of existing members are replaced.
+type PostTeamAddMemberJSONBody struct {
+	Members  []TeamMember `json:"members"`
+	TeamName string       `json:"team_name"`
+}
+
+// PostTeamRemoveMemberJSONBody defines parameters for PostTeamRemoveMember.
+type PostTeamRemoveMemberJSONBody struct {
+	// ReassignReviews reassign member's open reviews before removal, nil means service default
+	ReassignReviews *bool  `json:"reassign_reviews,omitempty"`
+	TeamName        string `json:"team_name"`
+	UserID          string `json:"user_id"`
+}
+
+// PostTeamRenameJSONBody defines parameters for PostTeamRename.
+type PostTeamRenameJSONBody struct {
+	NewTeamName string `json:"new_team_name"`
+	TeamName    string `json:"team_name"`
+}
+
+// PostTeamSetParentJSONBody defines parameters for PostTeamSetParent.
+type PostTeamSetParentJSONBody struct {
+	// ParentTeam empty makes the team top-level
+	ParentTeam string `json:"parent_team"`
+	TeamName   string `json:"team_name"`
+}
+
+// PostTeamSetArchivedJSONBody defines parameters for PostTeamSetArchived.
+type PostTeamSetArchivedJSONBody struct {
+	Archived bool   `json:"archived"`
+	TeamName string `json:"team_name"`
+}
+
+// PostTeamDeleteJSONBody defines parameters for PostTeamDelete.
+type PostTeamDeleteJSONBody struct {
+	TeamName string `json:"team_name"`
+}
+
+type TeamMemberRemovedResponse struct {
+	Team Team `json:"team"`
+	// Reassigned open reviews handed over to other reviewers
+	Reassigned []ReviewHandover `json:"reassigned,omitempty"`
+	// NotReassigned open reviews left with the removed member
+	NotReassigned []ReviewHandover `json:"not_reassigned,omitempty"`
+}
+
+type TeamDeletedResponse struct {
+	TeamName string `json:"team_name"`
+}
+
+// TeamTree defines a team with its nested teams.
+type TeamTree struct {
+	TeamName string     `json:"team_name"`
+	Children []TeamTree `json:"children"`
+}
+
+type TeamAncestorsResponse struct {
+	TeamName string `json:"team_name"`
+	// Ancestors parent first, top-level team last
+	Ancestors []string `json:"ancestors"`
+}
@@ -2,20 +2,118 @@ package domain
 
 // PostUsersSetIsActiveJSONBody defines parameters for PostUsersSetIsActive.
 type PostUsersSetIsActiveJSONBody struct {
-	IsActive bool   `json:"is_active"`
+	IsActive bool `json:"is_active"`
+	// ReassignReviews reassign user's open reviews on deactivation, nil means service default
+	ReassignReviews *bool  `json:"reassign_reviews,omitempty"`
+	UserID          string `json:"user_id"`
+}
+
+// PostUsersSetMaxOpenReviewsJSONBody defines parameters for PostUsersSetMaxOpenReviews.
+type PostUsersSetMaxOpenReviewsJSONBody struct {
+	// MaxOpenReviews nil removes the limit
+	MaxOpenReviews *int   `json:"max_open_reviews"`
+	UserID         string `json:"user_id"`
+}
+
+// PostUsersSetPrimaryTeamJSONBody defines parameters for PostUsersSetPrimaryTeam.
+type PostUsersSetPrimaryTeamJSONBody struct {
+	TeamName string `json:"team_name"`
 	UserID   string `json:"user_id"`
 }
 
 // User defines model for User.
 type User struct {
-	IsActive bool   `json:"is_active"`
+	IsActive bool `json:"is_active"`
+	// MaxOpenReviews limit of concurrent OPEN reviews, nil means unlimited
+	MaxOpenReviews *int `json:"max_open_reviews,omitempty"`
+	// TeamName primary team, its settings and members are used for assignment by default
 	TeamName string `json:"team_name"`
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
+	// Teams every team of the user, primary first
+	Teams    []string `json:"teams,omitempty"`
+	UserID   string   `json:"user_id"`
+	Username string   `json:"username"`
+}
+
+// InTeam reports whether user is a member of the team.
+func (u *User) InTeam(teamName string) bool {
+	for _, t := range u.Teams {
+		if t == teamName {
+			return true
+		}
+	}
+	return false
+}
+
+// UserResponse defines model for single user response.
+type UserResponse struct {
+	User User `json:"user"`
+}
+
+// ReviewHandover defines result of moving one open review to another reviewer.
+type ReviewHandover struct {
+	PullRequestID string `json:"pull_request_id"`
+	// ReplacedBy empty if no candidate was available and review stays with the old reviewer
+	ReplacedBy string `json:"replaced_by,omitempty"`
 }
 
 type UserUpdActiveResponse struct {
 	User User `json:"user"`
+	// Reassigned open reviews handed over to other reviewers
+	Reassigned []ReviewHandover `json:"reassigned,omitempty"`
+	// NotReassigned open reviews left without replacement
+	NotReassigned []ReviewHandover `json:"not_reassigned,omitempty"`
+}
+
+// PostUsersDeactivateBatchJSONBody defines parameters for PostUsersDeactivateBatch.
+// Users of the team and listed users are deactivated together.
+type PostUsersDeactivateBatchJSONBody struct {
+	TeamName string   `json:"team_name,omitempty"`
+	UserIDs  []string `json:"user_ids,omitempty"`
+}
+
+// ReviewerReplacement defines a reviewer swapped out of a pull request.
+type ReviewerReplacement struct {
+	OldUserID  string `json:"old_user_id"`
+	ReplacedBy string `json:"replaced_by"`
+}
+
+// PullRequestHandover defines handover result of one open pull request.
+type PullRequestHandover struct {
+	PullRequestID string                `json:"pull_request_id"`
+	Reassigned    []ReviewerReplacement `json:"reassigned"`
+	// NotReassigned deactivated reviewers left in place for lack of candidates
+	NotReassigned []string `json:"not_reassigned"`
+}
+
+type DeactivateBatchResponse struct {
+	Users        []User                `json:"users"`
+	PullRequests []PullRequestHandover `json:"pull_requests"`
+}
+
+// UserDetailsResponse defines user with their current review load.
+type UserDetailsResponse struct {
+	User User `json:"user"`
+	// OpenReviews number of OPEN pull requests the user reviews
+	OpenReviews int `json:"open_reviews"`
+}
+
+// GetUsersListParams defines parameters for GetUsersList.
+// Empty filters are not applied, users are sorted by username.
+type GetUsersListParams struct {
+	TeamName string
+	IsActive *bool
+	// UsernamePrefix case-insensitive beginning of username
+	UsernamePrefix string
+	Limit          int
+	// Cursor opaque position returned as next_cursor by the previous page
+	Cursor string
+}
+
+// UserListResponse defines a page of users.
+type UserListResponse struct {
+	Users []User `json:"users"`
+	// NextCursor empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 type UserReviewsResponse struct {
@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Egorrrad/avitotechBackendPR/internal/domain"
+)
+
+// authorizeActor allows actor to override rules of the team: configured admins,
+// and active maintainers or the lead of the team.
+func (s *Service) authorizeActor(ctx context.Context, actor, teamName string) error {
+	if s.admins[actor] {
+		return nil
+	}
+	if actor == "" || teamName == "" {
+		return domain.ErrForbidden
+	}
+
+	privileged, err := s.teams.GetMembersByRole(ctx, teamName, domain.TeamRoleMaintainer, domain.TeamRoleLead)
+	if err != nil {
+		return err
+	}
+	if !containsAny(privileged, []string{actor}) {
+		return domain.ErrForbidden
+	}
+
+	user, err := s.users.GetByID(ctx, actor)
+	if err != nil {
+		return err
+	}
+	if user == nil || !user.IsActive {
+		return domain.ErrForbidden
+	}
+	return nil
+}
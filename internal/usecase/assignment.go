@@ -0,0 +1,323 @@
+package usecase
+
+import (
+	"context"
+	"math/rand"
+
+	"github.com/Egorrrad/avitotechBackendPR/internal/domain"
+)
+
+type assignment struct {
+	reviewers []string
+	// fallback reviewers taken from fallback teams, subset of reviewers
+	fallback []domain.FallbackReviewer
+	// atCapacity eligible candidates skipped because of max_open_reviews
+	atCapacity int
+	// maintainerMissing the team requires a maintainer among reviewers but none could be picked
+	maintainerMissing bool
+}
+
+// assignmentRequest describes reviewer slots to fill for a pull request.
+type assignmentRequest struct {
+	teamName string
+	settings *domain.TeamSettings
+	// exclude users that must not be picked, picked users are added to it
+	exclude map[string]bool
+	// assigned reviewers that stay on the PR, they count towards code owner and maintainer coverage
+	assigned []string
+	// ownerGroups active code owners of each rule matching changed paths
+	ownerGroups [][]string
+	count       int
+	rng         *rand.Rand
+	// strategy overrides strategies of the team and its fallback teams when set
+	strategy domain.ReviewerStrategy
+}
+
+// strategyFor returns strategy used to pick from candidates of a team with the settings.
+func (req *assignmentRequest) strategyFor(settings *domain.TeamSettings) domain.ReviewerStrategy {
+	if req.strategy != "" {
+		return req.strategy
+	}
+	return settings.ReviewerStrategy
+}
+
+// priorityStrategy returns strategy override for pull requests of the priority,
+// urgent ones go to reviewers with the lowest current load.
+func priorityStrategy(priority domain.PullRequestPriority) domain.ReviewerStrategy {
+	if priority.IsUrgent() {
+		return domain.ReviewerStrategyLeastLoaded
+	}
+	return ""
+}
+
+// pickReviewers fills up to count slots: first with a maintainer if the team requires one,
+// then with code owners of changed paths, then from the team, from its fallback teams
+// in declared order and, with hierarchy escalation on, from sibling and parent teams
+// up the hierarchy.
+func (s *Service) pickReviewers(ctx context.Context, req assignmentRequest) (*assignment, error) {
+	result := &assignment{reviewers: []string{}}
+
+	if err := s.pickMaintainer(ctx, req, result); err != nil {
+		return nil, err
+	}
+
+	if err := s.pickCodeOwners(ctx, req, result); err != nil {
+		return nil, err
+	}
+
+	teams := append([]string{req.teamName}, req.settings.FallbackTeams...)
+	if err := s.pickFromTeams(ctx, req, result, teams, 1); err != nil {
+		return nil, err
+	}
+
+	if !s.hierarchyEscalation || req.teamName == "" || len(result.reviewers) >= req.count {
+		return result, nil
+	}
+
+	escalation, err := s.escalationTeams(ctx, req.teamName, teams)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.pickFromTeams(ctx, req, result, escalation, 0); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// pickFromTeams fills remaining slots from teams in order, reviewers of teams
+// starting at index fallbackFrom are reported as fallback reviewers.
+func (s *Service) pickFromTeams(ctx context.Context, req assignmentRequest, result *assignment,
+	teams []string, fallbackFrom int) error {
+	for i, team := range teams {
+		need := req.count - len(result.reviewers)
+		if need <= 0 {
+			break
+		}
+
+		teamSettings := req.settings
+		if team != req.teamName {
+			var err error
+			if teamSettings, err = s.teamSettings(ctx, team); err != nil {
+				return err
+			}
+		}
+
+		candidates, full, err := s.getEligibleCandidates(ctx, team, req.exclude)
+		if err != nil {
+			return err
+		}
+		result.atCapacity += full
+
+		selected, err := s.selectReviewers(ctx, req.rng, team, req.strategyFor(teamSettings), candidates, need)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range selected {
+			req.exclude[id] = true
+			result.reviewers = append(result.reviewers, id)
+			if i >= fallbackFrom {
+				result.fallback = append(result.fallback, domain.FallbackReviewer{UserID: id, TeamName: team})
+			}
+		}
+	}
+
+	return nil
+}
+
+// escalationTeams walks up from the team: on every level come the siblings of the
+// current team, then its parent. Teams in visited are skipped.
+func (s *Service) escalationTeams(ctx context.Context, teamName string, visited []string) ([]string, error) {
+	ancestors, err := s.teams.GetAncestors(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(visited))
+	for _, team := range visited {
+		seen[team] = true
+	}
+
+	var teams []string
+	add := func(team string) {
+		if !seen[team] {
+			seen[team] = true
+			teams = append(teams, team)
+		}
+	}
+
+	current := teamName
+	for _, parent := range ancestors {
+		siblings, err := s.teams.GetChildren(ctx, parent)
+		if err != nil {
+			return nil, err
+		}
+		for _, sibling := range siblings {
+			if sibling != current {
+				add(sibling)
+			}
+		}
+		add(parent)
+		current = parent
+	}
+
+	return teams, nil
+}
+
+// pickCodeOwners picks one reviewer for every owner group not yet covered by a reviewer.
+// In REQUIRED mode every group has to be covered.
+func (s *Service) pickCodeOwners(ctx context.Context, req assignmentRequest, result *assignment) error {
+	required := req.settings.CodeOwnersMode == domain.CodeOwnersModeRequired
+
+	for _, group := range req.ownerGroups {
+		if containsAny(group, req.assigned) || containsAny(group, result.reviewers) {
+			continue
+		}
+
+		var candidates []string
+		for _, id := range group {
+			if !req.exclude[id] {
+				candidates = append(candidates, id)
+			}
+		}
+
+		if len(candidates) == 0 || len(result.reviewers) >= req.count {
+			if required {
+				return domain.ErrCodeOwnersUnavailable
+			}
+			continue
+		}
+
+		selected, err := s.selectReviewers(ctx, req.rng, req.teamName, req.strategyFor(req.settings), candidates, 1)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range selected {
+			req.exclude[id] = true
+			result.reviewers = append(result.reviewers, id)
+		}
+	}
+
+	return nil
+}
+
+// pickMaintainer reserves a slot for one maintainer or lead of the team when the team
+// requires one and none of the assigned reviewers is. A maintainer who owns changed
+// code is preferred, so the slot covers code owners too. The rule is reported as unmet
+// only when no maintainer can be picked; slots are then filled from everyone as usual.
+func (s *Service) pickMaintainer(ctx context.Context, req assignmentRequest, result *assignment) error {
+	if !req.settings.RequireMaintainer || req.teamName == "" || req.count <= 0 {
+		return nil
+	}
+
+	maintainers, err := s.teams.GetMembersByRole(ctx, req.teamName, domain.TeamRoleMaintainer, domain.TeamRoleLead)
+	if err != nil {
+		return err
+	}
+	if containsAny(maintainers, req.assigned) {
+		return nil
+	}
+
+	eligible, _, err := s.getEligibleCandidates(ctx, req.teamName, req.exclude)
+	if err != nil {
+		return err
+	}
+
+	var candidates, owners []string
+	for _, id := range eligible {
+		if !containsAny(maintainers, []string{id}) {
+			continue
+		}
+		candidates = append(candidates, id)
+		for _, group := range req.ownerGroups {
+			if containsAny(group, []string{id}) && !containsAny(group, req.assigned) {
+				owners = append(owners, id)
+				break
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		result.maintainerMissing = true
+		return nil
+	}
+	if len(owners) > 0 {
+		candidates = owners
+	}
+
+	selected, err := s.selectReviewers(ctx, req.rng, req.teamName, req.strategyFor(req.settings), candidates, 1)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range selected {
+		req.exclude[id] = true
+		result.reviewers = append(result.reviewers, id)
+	}
+	return nil
+}
+
+func containsAny(list, values []string) bool {
+	for _, v := range values {
+		for _, item := range list {
+			if item == v {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getEligibleCandidates returns active team members not excluded and below their review capacity,
+// and the number of members skipped only because of capacity.
+func (s *Service) getEligibleCandidates(ctx context.Context, teamName string,
+	exclude map[string]bool) ([]string, int, error) {
+	teamMembers, err := s.users.GetByTeamActive(ctx, teamName)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var members []domain.User
+	for _, m := range teamMembers {
+		if !exclude[m.UserID] {
+			members = append(members, m)
+		}
+	}
+
+	candidates, full, err := s.splitByCapacity(ctx, members)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return candidates, len(full), nil
+}
+
+func (s *Service) selectReviewers(ctx context.Context, rng *rand.Rand, teamName string,
+	strategy domain.ReviewerStrategy, candidates []string, count int) ([]string, error) {
+	if strategy == "" {
+		strategy = s.defaultStrategy
+	}
+
+	selector, ok := s.selectors[strategy]
+	if !ok {
+		return nil, domain.ErrInvalidReviewerStrategy
+	}
+
+	return selector.Select(ctx, SelectionRequest{
+		TeamName:   teamName,
+		Candidates: candidates,
+		Count:      count,
+		Rand:       rng,
+	})
+}
+
+// teamSettings returns team settings, users without team get defaults.
+func (s *Service) teamSettings(ctx context.Context, teamName string) (*domain.TeamSettings, error) {
+	if teamName == "" {
+		settings := domain.DefaultTeamSettings()
+		return &settings, nil
+	}
+
+	return s.teams.GetSettings(ctx, teamName)
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Egorrrad/avitotechBackendPR/internal/domain"
+)
+
+func (s *Service) AddUnavailability(ctx context.Context,
+	req *domain.PostUsersUnavailabilityAddJSONBody) (*domain.UnavailabilityResponse, error) {
+	period := &domain.Unavailability{
+		UserID:   req.UserID,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+		Reason:   req.Reason,
+	}
+
+	if !period.EndsAt.After(period.StartsAt) {
+		return nil, domain.ErrInvalidUnavailability
+	}
+
+	user, err := s.users.GetByID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	if err := s.availability.Create(ctx, period); err != nil {
+		return nil, err
+	}
+
+	return &domain.UnavailabilityResponse{Unavailability: *period}, nil
+}
+
+func (s *Service) GetUserUnavailability(ctx context.Context, userID string) (*domain.UserUnavailabilityResponse, error) {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	periods, err := s.availability.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.UserUnavailabilityResponse{
+		UserID:         userID,
+		Unavailability: periods,
+	}, nil
+}
+
+func (s *Service) UpdateUnavailability(ctx context.Context,
+	req *domain.PostUsersUnavailabilityUpdateJSONBody) (*domain.UnavailabilityResponse, error) {
+	if !req.EndsAt.After(req.StartsAt) {
+		return nil, domain.ErrInvalidUnavailability
+	}
+
+	period, err := s.availability.GetByID(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if period == nil {
+		return nil, domain.ErrUnavailabilityNotFound
+	}
+
+	period.StartsAt = req.StartsAt
+	period.EndsAt = req.EndsAt
+	period.Reason = req.Reason
+
+	if err := s.availability.Update(ctx, period); err != nil {
+		return nil, err
+	}
+
+	return &domain.UnavailabilityResponse{Unavailability: *period}, nil
+}
+
+func (s *Service) DeleteUnavailability(ctx context.Context, id int64) (*domain.UnavailabilityResponse, error) {
+	period, err := s.availability.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if period == nil {
+		return nil, domain.ErrUnavailabilityNotFound
+	}
+
+	if err := s.availability.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return &domain.UnavailabilityResponse{Unavailability: *period}, nil
+}
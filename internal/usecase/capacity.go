@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Egorrrad/avitotechBackendPR/internal/domain"
+)
+
+// splitByCapacity separates users who can take one more review from those
+// already holding max_open_reviews OPEN reviews.
+func (s *Service) splitByCapacity(ctx context.Context, users []domain.User) (available, full []string, err error) {
+	var limited []string
+	for _, u := range users {
+		if u.MaxOpenReviews != nil {
+			limited = append(limited, u.UserID)
+		}
+	}
+
+	var loads map[string]int
+	if len(limited) > 0 {
+		if loads, err = s.pr.CountOpenReviews(ctx, limited); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for _, u := range users {
+		if u.MaxOpenReviews != nil && loads[u.UserID] >= *u.MaxOpenReviews {
+			full = append(full, u.UserID)
+		} else {
+			available = append(available, u.UserID)
+		}
+	}
+
+	return available, full, nil
+}
+
+func validateReviewCapacity(maxOpenReviews *int) error {
+	if maxOpenReviews != nil && *maxOpenReviews < 0 {
+		return domain.ErrInvalidReviewCapacity
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"bufio"
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/Egorrrad/avitotechBackendPR/internal/domain"
+)
+
+func (s *Service) GetCodeOwners(ctx context.Context) (*domain.CodeOwnersResponse, error) {
+	rules, err := s.owners.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.CodeOwnersResponse{Rules: rules}, nil
+}
+
+func (s *Service) SetCodeOwners(ctx context.Context, rules []domain.CodeOwnerRule) (*domain.CodeOwnersResponse, error) {
+	for i := range rules {
+		if err := normalizeCodeOwnerRule(&rules[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.owners.Replace(ctx, rules); err != nil {
+		return nil, err
+	}
+
+	return &domain.CodeOwnersResponse{Rules: rules}, nil
+}
+
+// ImportCodeOwners replaces all rules with ones parsed from CODEOWNERS file content.
+func (s *Service) ImportCodeOwners(ctx context.Context, content string) (*domain.CodeOwnersResponse, error) {
+	rules, err := ParseCodeOwners(content)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.SetCodeOwners(ctx, rules)
+}
+
+// ParseCodeOwners parses CODEOWNERS format: "<pattern> @user @org/team ...".
+// Owners with a slash are treated as teams, the part after the last slash is the team name.
+func ParseCodeOwners(content string) ([]domain.CodeOwnerRule, error) {
+	rules := make([]domain.CodeOwnerRule, 0)
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		rule := domain.CodeOwnerRule{Pattern: fields[0]}
+		for _, owner := range fields[1:] {
+			if strings.HasPrefix(owner, "#") {
+				break
+			}
+
+			owner = strings.TrimPrefix(owner, "@")
+			if i := strings.LastIndex(owner, "/"); i >= 0 {
+				rule.Teams = append(rule.Teams, owner[i+1:])
+			} else {
+				rule.Users = append(rule.Users, owner)
+			}
+		}
+
+		if err := normalizeCodeOwnerRule(&rule); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, scanner.Err()
+}
+
+func normalizeCodeOwnerRule(rule *domain.CodeOwnerRule) error {
+	if rule.Pattern == "" {
+		return domain.ErrInvalidCodeOwners
+	}
+	if _, err := compileOwnerPattern(rule.Pattern); err != nil {
+		return domain.ErrInvalidCodeOwners
+	}
+
+	if rule.Users == nil {
+		rule.Users = []string{}
+	}
+	if rule.Teams == nil {
+		rule.Teams = []string{}
+	}
+	return nil
+}
+
+// compileOwnerPattern converts gitignore-style pattern used in CODEOWNERS to regexp.
+// Patterns without a slash match at any depth, "*" does not cross directories,
+// "**" does, and a pattern matching a directory matches everything inside it.
+func compileOwnerPattern(pattern string) (*regexp.Regexp, error) {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				b.WriteString(".*")
+				i++
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	if dirOnly {
+		b.WriteString("/.*$")
+	} else {
+		b.WriteString("(?:/.*)?$")
+	}
+
+	return regexp.Compile(b.String())
+}
+
+// codeOwnerGroups returns active owners of each rule matching changed paths.
+// For every path only the last matching rule applies.
+func (s *Service) codeOwnerGroups(ctx context.Context, paths []string) ([][]string, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	rules, err := s.owners.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matchers := make([]*regexp.Regexp, len(rules))
+	for i, rule := range rules {
+		if matchers[i], err = compileOwnerPattern(rule.Pattern); err != nil {
+			return nil, err
+		}
+	}
+
+	matched := make(map[int]bool)
+	var order []int
+	for _, path := range paths {
+		path = strings.TrimPrefix(path, "/")
+		for i := len(rules) - 1; i >= 0; i-- {
+			if matchers[i].MatchString(path) {
+				if !matched[i] {
+					matched[i] = true
+					order = append(order, i)
+				}
+				break
+			}
+		}
+	}
+
+	groups := make([][]string, 0, len(order))
+	for _, i := range order {
+		owners, err := s.activeRuleOwners(ctx, rules[i])
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, owners)
+	}
+
+	return groups, nil
+}
+
+// activeRuleOwners returns active owners of the rule below their review capacity.
+func (s *Service) activeRuleOwners(ctx context.Context, rule domain.CodeOwnerRule) ([]string, error) {
+	seen := make(map[string]bool)
+	var owners []domain.User
+
+	users, err := s.users.GetActiveByIDs(ctx, rule.Users)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range users {
+		if !seen[u.UserID] {
+			seen[u.UserID] = true
+			owners = append(owners, u)
+		}
+	}
+
+	for _, team := range rule.Teams {
+		members, err := s.users.GetByTeamActive(ctx, team)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range members {
+			if !seen[m.UserID] {
+				seen[m.UserID] = true
+				owners = append(owners, m)
+			}
+		}
+	}
+
+	available, _, err := s.splitByCapacity(ctx, owners)
+	return available, err
+}
@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Egorrrad/avitotechBackendPR/internal/domain"
+)
+
+// GetPullRequestHistory returns every recorded change of the pull request, oldest first.
+func (s *Service) GetPullRequestHistory(ctx context.Context, prID string) (*domain.PullRequestHistoryResponse, error) {
+	if _, err := s.getPullRequest(ctx, prID); err != nil {
+		return nil, err
+	}
+
+	events, err := s.pr.GetEvents(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PullRequestHistoryResponse{PullRequestID: prID, Events: events}, nil
+}
+
+func statusChangedEvent(from, to domain.PullRequestStatus) domain.PREvent {
+	return domain.PREvent{Type: domain.PREventStatusChanged, FromStatus: from, ToStatus: to}
+}
+
+// assignedEvents describes assignment of reviewers, those in restored came back after reopen,
+// the others were picked with seed.
+func assignedEvents(reviewers, restored []string, seed *int64) []domain.PREvent {
+	wasRestored := make(map[string]bool, len(restored))
+	for _, id := range restored {
+		wasRestored[id] = true
+	}
+
+	events := make([]domain.PREvent, 0, len(reviewers))
+	for _, id := range reviewers {
+		event := domain.PREvent{Type: domain.PREventReviewerAssigned, ReviewerID: id}
+		if wasRestored[id] {
+			event.Details = "restored after reopen"
+		} else {
+			event.Seed = seed
+		}
+		events = append(events, event)
+	}
+	return events
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import "github.com/Egorrrad/avitotechBackendPR/internal/domain"
+
+// Option -.
+type Option func(*Service)
+
+// DefaultStrategy -.
+func DefaultStrategy(strategy domain.ReviewerStrategy) Option {
+	return func(s *Service) {
+		s.defaultStrategy = strategy
+	}
+}
+
+// WithSelector -.
+func WithSelector(strategy domain.ReviewerStrategy, selector ReviewerSelector) Option {
+	return func(s *Service) {
+		s.selectors[strategy] = selector
+	}
+}
+
+// Deterministic -.
+func Deterministic(enabled bool) Option {
+	return func(s *Service) {
+		s.deterministic = enabled
+	}
+}
+
+// WithSeedSource -.
+func WithSeedSource(seeds SeedSource) Option {
+	return func(s *Service) {
+		s.seeds = seeds
+	}
+}
+
+// ReassignOnDeactivate -.
+func ReassignOnDeactivate(enabled bool) Option {
+	return func(s *Service) {
+		s.reassignOnDeactivate = enabled
+	}
+}
+
+// MaxReassignments -.
+func MaxReassignments(limit int) Option {
+	return func(s *Service) {
+		s.maxReassignments = limit
+	}
+}
+
+// Admins -.
+func Admins(userIDs ...string) Option {
+	return func(s *Service) {
+		for _, id := range userIDs {
+			if id != "" {
+				s.admins[id] = true
+			}
+		}
+	}
+}
+
+// RestrictReviewerEdits -.
+func RestrictReviewerEdits(enabled bool) Option {
+	return func(s *Service) {
+		s.restrictReviewerEdits = enabled
+	}
+}
+
+// HierarchyEscalation -.
+func HierarchyEscalation(enabled bool) Option {
+	return func(s *Service) {
+		s.hierarchyEscalation = enabled
+	}
+}
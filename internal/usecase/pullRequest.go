@@ -2,65 +2,470 @@ package usecase
 
 import (
 	"context"
-	"math/rand"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Egorrrad/avitotechBackendPR/internal/domain"
 )
 
-func (s *Service) CreatePullRequest(ctx context.Context, prID, authorID, name string) (*domain.PullRequestResponse, error) {
-	exists, err := s.pr.Exists(ctx, prID)
+func (s *Service) CreatePullRequest(ctx context.Context,
+	req *domain.PostPullRequestCreateJSONBody) (*domain.PullRequestResponse, error) {
+	priority, err := parsePriority(req.Priority)
 	if err != nil {
 		return nil, err
 	}
-	if exists {
-		return nil, domain.ErrPRAlreadyExists
+
+	return runInTx(ctx, s.tx, func(ctx context.Context) (*domain.PullRequestResponse, error) {
+		prID, authorID := req.PullRequestID, req.AuthorID
+
+		exists, err := s.pr.Exists(ctx, prID)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, domain.ErrPRAlreadyExists
+		}
+
+		author, err := s.users.GetByID(ctx, authorID)
+		if err != nil {
+			return nil, err
+		}
+		if author == nil {
+			return nil, domain.ErrUserNotFound
+		}
+
+		teamName := author.TeamName
+		if req.TeamName != "" {
+			if !author.InTeam(req.TeamName) {
+				return nil, domain.ErrNotTeamMember
+			}
+			teamName = req.TeamName
+		}
+
+		now := time.Now()
+		newPR := &domain.PullRequest{
+			PullRequestID:     prID,
+			PullRequestName:   req.PullRequestName,
+			AuthorID:          authorID,
+			ChangedPaths:      req.ChangedPaths,
+			Priority:          priority,
+			Labels:            normalizeLabels(req.Labels),
+			Status:            domain.PullRequestStatusOPEN,
+			AssignedReviewers: []string{},
+			CreatedAt:         &now,
+			TeamName:          teamName,
+		}
+
+		// reviewers of a draft are assigned when it is marked ready
+		picked := &assignment{}
+		if req.Draft {
+			newPR.Status = domain.PullRequestStatusDRAFT
+		} else if picked, err = s.assignReviewers(ctx, newPR, teamName, nil); err != nil {
+			return nil, err
+		}
+
+		if _, err := s.pr.Create(ctx, newPR); err != nil {
+			return nil, err
+		}
+
+		events := append([]domain.PREvent{{
+			Type:     domain.PREventCreated,
+			Actor:    authorID,
+			ToStatus: newPR.Status,
+		}}, assignedEvents(newPR.AssignedReviewers, nil, newPR.AssignmentSeed)...)
+		if err := s.pr.AppendEvents(ctx, prID, events...); err != nil {
+			return nil, err
+		}
+
+		respNewPr := &domain.PullRequestResponse{
+			PR:                *newPR,
+			FallbackReviewers: picked.fallback,
+			MaintainerMissing: picked.maintainerMissing,
+		}
+
+		return respNewPr, nil
+	})
+}
+
+// parsePriority accepts priority in any case, empty means NORMAL.
+func parsePriority(p domain.PullRequestPriority) (domain.PullRequestPriority, error) {
+	if p == "" {
+		return domain.PriorityNormal, nil
+	}
+
+	priority := domain.PullRequestPriority(strings.ToUpper(string(p)))
+	if !priority.IsValid() {
+		return "", domain.ErrInvalidPriority
+	}
+	return priority, nil
+}
+
+// normalizeLabels trims labels and drops empty and repeated ones, keeping the first occurrence order.
+func normalizeLabels(labels []string) []string {
+	seen := make(map[string]bool, len(labels))
+	normalized := make([]string, 0, len(labels))
+	for _, l := range labels {
+		l = strings.TrimSpace(l)
+		if l == "" || seen[l] {
+			continue
+		}
+		seen[l] = true
+		normalized = append(normalized, l)
+	}
+	return normalized
+}
+
+// assignReviewers fills reviewer slots of pr up to the team's reviewer limit for its priority,
+// reviewers in keep stay assigned and take slots first. More reviewers in keep than the limit
+// allows is reported as ErrReviewerLimitReached.
+func (s *Service) assignReviewers(ctx context.Context, pr *domain.PullRequest, teamName string,
+	keep []string) (*assignment, error) {
+	settings, err := s.teamSettings(ctx, teamName)
+	if err != nil {
+		return nil, err
 	}
 
-	author, err := s.users.GetByID(ctx, authorID)
+	ownerGroups, err := s.codeOwnerGroups(ctx, pr.ChangedPaths)
 	if err != nil {
 		return nil, err
 	}
-	if author == nil {
-		return nil, domain.ErrUserNotFound
+
+	limit := settings.ReviewerLimit(pr.Priority)
+	if len(keep) > limit {
+		return nil, domain.ErrReviewerLimitReached
 	}
 
-	teamMembers, err := s.users.GetByTeamActive(ctx, author.TeamName)
+	exclude := map[string]bool{pr.AuthorID: true}
+	for _, id := range keep {
+		exclude[id] = true
+	}
+	for _, id := range pr.RemovedReviewers {
+		exclude[id] = true
+	}
+
+	req := assignmentRequest{
+		teamName:    teamName,
+		settings:    settings,
+		exclude:     exclude,
+		assigned:    keep,
+		ownerGroups: ownerGroups,
+		count:       limit - len(keep),
+		strategy:    priorityStrategy(pr.Priority),
+	}
+	seed, err := s.seedAssignment(ctx, pr.PullRequestID, &req)
 	if err != nil {
 		return nil, err
 	}
 
-	candidates := make([]string, 0)
-	for _, m := range teamMembers {
-		if m.UserID != authorID {
-			candidates = append(candidates, m.UserID)
+	picked, err := s.pickReviewers(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	total := len(keep) + len(picked.reviewers)
+	if picked.atCapacity > 0 && (total == 0 || total < settings.MinReviewers) {
+		return nil, domain.ErrReviewersAtCapacity
+	}
+	if total < settings.MinReviewers {
+		return nil, domain.ErrNotEnoughReviewers
+	}
+
+	reviewers := make([]string, 0, total)
+	pr.AssignedReviewers = append(append(reviewers, keep...), picked.reviewers...)
+	pr.AssignmentSeed = &seed
+
+	return picked, nil
+}
+
+// MergePullRequest merges OPEN pull request if it meets merge policy of the author's team.
+// Forced merge skips the check and is recorded for audit.
+func (s *Service) MergePullRequest(ctx context.Context,
+	req *domain.PostPullRequestMergeJSONBody) (*domain.PullRequestResponse, error) {
+	if req.Force && req.Actor == "" {
+		return nil, domain.ErrForceActorRequired
+	}
+
+	var resp *domain.PullRequestResponse
+	err := s.tx.RunInTx(ctx, func(ctx context.Context) error {
+		pr, err := s.getPullRequest(ctx, req.PullRequestID)
+		if err != nil {
+			return err
+		}
+
+		switch pr.Status {
+		case domain.PullRequestStatusMERGED:
+			resp = &domain.PullRequestResponse{PR: *pr}
+			return nil
+		case domain.PullRequestStatusOPEN:
+		default:
+			return domain.ErrInvalidTransition
+		}
+
+		if req.Force {
+			teamName, err := s.prTeam(ctx, pr)
+			if err != nil {
+				return err
+			}
+			if err := s.authorizeActor(ctx, req.Actor, teamName); err != nil {
+				return err
+			}
+		}
+
+		missing, err := s.unmetMergeConditions(ctx, pr)
+		if err != nil {
+			return err
+		}
+
+		if req.Force {
+			if err := s.pr.RecordForcedMerge(ctx, pr.PullRequestID, req.Actor, missing); err != nil {
+				return err
+			}
+		} else if len(missing) > 0 {
+			return &domain.MergeBlockedError{Missing: missing}
 		}
+
+		mergedAt := time.Now()
+		pr.Status = domain.PullRequestStatusMERGED
+		pr.MergedAt = &mergedAt
+
+		if err := s.pr.Update(ctx, pr); err != nil {
+			return err
+		}
+
+		event := domain.PREvent{
+			Type:       domain.PREventMerged,
+			Actor:      req.Actor,
+			FromStatus: domain.PullRequestStatusOPEN,
+			ToStatus:   domain.PullRequestStatusMERGED,
+		}
+		if req.Force {
+			event.Details = "forced"
+			if len(missing) > 0 {
+				event.Details += "; unmet: " + strings.Join(missing, ", ")
+			}
+		}
+		if err := s.pr.AppendEvents(ctx, pr.PullRequestID, event); err != nil {
+			return err
+		}
+
+		resp = &domain.PullRequestResponse{PR: *pr}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	reviewers := selectRandomReviewers(candidates, 2)
+	return resp, nil
+}
 
-	now := time.Now()
-	newPR := &domain.PullRequest{
-		PullRequestID:     prID,
-		PullRequestName:   name,
-		AuthorID:          authorID,
-		Status:            domain.PullRequestStatusOPEN,
-		AssignedReviewers: reviewers,
-		CreatedAt:         &now,
+// unmetMergeConditions describes every merge policy condition pr does not meet.
+func (s *Service) unmetMergeConditions(ctx context.Context, pr *domain.PullRequest) ([]string, error) {
+	teamName, err := s.prTeam(ctx, pr)
+	if err != nil {
+		return nil, err
 	}
 
-	if _, err := s.pr.Create(ctx, newPR); err != nil {
+	settings, err := s.teamSettings(ctx, teamName)
+	if err != nil {
 		return nil, err
 	}
+	policy := settings.MergePolicy
+
+	approvals := 0
+	var missing []string
+	for _, d := range pr.ReviewDecisions {
+		switch d.Decision {
+		case domain.ReviewDecisionApproved:
+			approvals++
+		case domain.ReviewDecisionChangesRequested:
+			if policy.BlockChangesRequested {
+				missing = append(missing, fmt.Sprintf("changes requested by %s", d.UserID))
+			}
+		}
+	}
 
-	respNewPr := &domain.PullRequestResponse{
-		PR: *newPR,
+	if approvals < policy.RequiredApprovals {
+		missing = append([]string{
+			fmt.Sprintf("%d of %d required approvals", approvals, policy.RequiredApprovals),
+		}, missing...)
 	}
 
-	return respNewPr, nil
+	return missing, nil
+}
+
+// MarkReady moves DRAFT pull request to OPEN and assigns reviewers,
+// reviewers added to the draft by hand keep their slots. If the team limit was lowered
+// below the number of manual reviewers, ErrReviewerLimitReached is returned.
+func (s *Service) MarkReady(ctx context.Context, prID string) (*domain.PullRequestResponse, error) {
+	return runInTx(ctx, s.tx, func(ctx context.Context) (*domain.PullRequestResponse, error) {
+		pr, err := s.getPullRequest(ctx, prID)
+		if err != nil {
+			return nil, err
+		}
+		if pr.Status != domain.PullRequestStatusDRAFT {
+			return nil, domain.ErrInvalidTransition
+		}
+
+		teamName, err := s.prTeam(ctx, pr)
+		if err != nil {
+			return nil, err
+		}
+
+		manual := pr.AssignedReviewers
+		picked, err := s.assignReviewers(ctx, pr, teamName, manual)
+		if err != nil {
+			return nil, err
+		}
+		pr.Status = domain.PullRequestStatusOPEN
+
+		if err := s.pr.Update(ctx, pr); err != nil {
+			return nil, err
+		}
+
+		events := append([]domain.PREvent{
+			statusChangedEvent(domain.PullRequestStatusDRAFT, domain.PullRequestStatusOPEN),
+		}, assignedEvents(excluding(pr.AssignedReviewers, manual), nil, pr.AssignmentSeed)...)
+		if err := s.pr.AppendEvents(ctx, prID, events...); err != nil {
+			return nil, err
+		}
+
+		return &domain.PullRequestResponse{
+			PR:                *pr,
+			FallbackReviewers: picked.fallback,
+			MaintainerMissing: picked.maintainerMissing,
+		}, nil
+	})
+}
+
+// ClosePullRequest closes OPEN or DRAFT pull request without merge and releases its reviewers.
+// Closing a CLOSED pull request is a no-op.
+func (s *Service) ClosePullRequest(ctx context.Context, prID string) (*domain.PullRequestResponse, error) {
+	return runInTx(ctx, s.tx, func(ctx context.Context) (*domain.PullRequestResponse, error) {
+		pr, err := s.getPullRequest(ctx, prID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch pr.Status {
+		case domain.PullRequestStatusCLOSED:
+			return &domain.PullRequestResponse{PR: *pr}, nil
+		case domain.PullRequestStatusOPEN, domain.PullRequestStatusDRAFT:
+		default:
+			return nil, domain.ErrInvalidTransition
+		}
+
+		events := []domain.PREvent{statusChangedEvent(pr.Status, domain.PullRequestStatusCLOSED)}
+		for _, id := range pr.AssignedReviewers {
+			events = append(events, domain.PREvent{Type: domain.PREventReviewerRemoved, ReviewerID: id})
+		}
+
+		pr.Status = domain.PullRequestStatusCLOSED
+		pr.ReleasedReviewers = pr.AssignedReviewers
+		pr.AssignedReviewers = []string{}
+		pr.ReviewDecisions = nil
+
+		if err := s.pr.Update(ctx, pr); err != nil {
+			return nil, err
+		}
+
+		if err := s.pr.AppendEvents(ctx, prID, events...); err != nil {
+			return nil, err
+		}
+
+		return &domain.PullRequestResponse{PR: *pr}, nil
+	})
 }
 
-func (s *Service) MergePullRequest(ctx context.Context, prID string) (*domain.PullRequestResponse, error) {
+// ReopenPullRequest moves CLOSED pull request back to OPEN. Reviewers released on close
+// are restored if they are still active, available and below capacity, up to the current reviewer limit,
+// free slots are filled as usual.
+func (s *Service) ReopenPullRequest(ctx context.Context, prID string) (*domain.PullRequestResponse, error) {
+	return runInTx(ctx, s.tx, func(ctx context.Context) (*domain.PullRequestResponse, error) {
+		pr, err := s.getPullRequest(ctx, prID)
+		if err != nil {
+			return nil, err
+		}
+		if pr.Status != domain.PullRequestStatusCLOSED {
+			return nil, domain.ErrInvalidTransition
+		}
+
+		teamName, err := s.prTeam(ctx, pr)
+		if err != nil {
+			return nil, err
+		}
+
+		restored, err := s.eligibleReleasedReviewers(ctx, pr)
+		if err != nil {
+			return nil, err
+		}
+
+		settings, err := s.teamSettings(ctx, teamName)
+		if err != nil {
+			return nil, err
+		}
+		if limit := settings.ReviewerLimit(pr.Priority); len(restored) > limit {
+			restored = restored[:limit]
+		}
+
+		picked, err := s.assignReviewers(ctx, pr, teamName, restored)
+		if err != nil {
+			return nil, err
+		}
+		pr.Status = domain.PullRequestStatusOPEN
+		pr.ReleasedReviewers = nil
+
+		if err := s.pr.Update(ctx, pr); err != nil {
+			return nil, err
+		}
+
+		events := append([]domain.PREvent{
+			statusChangedEvent(domain.PullRequestStatusCLOSED, domain.PullRequestStatusOPEN),
+		}, assignedEvents(pr.AssignedReviewers, restored, pr.AssignmentSeed)...)
+		if err := s.pr.AppendEvents(ctx, prID, events...); err != nil {
+			return nil, err
+		}
+
+		pr, err = s.pr.GetByID(ctx, prID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &domain.PullRequestResponse{
+			PR:                *pr,
+			FallbackReviewers: picked.fallback,
+			MaintainerMissing: picked.maintainerMissing,
+		}, nil
+	})
+}
+
+// eligibleReleasedReviewers returns released reviewers who could be assigned now, in original order.
+func (s *Service) eligibleReleasedReviewers(ctx context.Context, pr *domain.PullRequest) ([]string, error) {
+	users, err := s.users.GetActiveByIDs(ctx, pr.ReleasedReviewers)
+	if err != nil {
+		return nil, err
+	}
+
+	available, _, err := s.splitByCapacity(ctx, users)
+	if err != nil {
+		return nil, err
+	}
+
+	eligible := make(map[string]bool, len(available))
+	for _, id := range available {
+		eligible[id] = true
+	}
+
+	var restored []string
+	for _, id := range pr.ReleasedReviewers {
+		if eligible[id] && id != pr.AuthorID {
+			restored = append(restored, id)
+		}
+	}
+	return restored, nil
+}
+
+func (s *Service) getPullRequest(ctx context.Context, prID string) (*domain.PullRequest, error) {
 	pr, err := s.pr.GetByID(ctx, prID)
 	if err != nil {
 		return nil, err
@@ -68,43 +473,208 @@ func (s *Service) MergePullRequest(ctx context.Context, prID string) (*domain.Pu
 	if pr == nil {
 		return nil, domain.ErrPullRequestNotFound
 	}
+	return pr, nil
+}
 
-	if pr.Status == domain.PullRequestStatusMERGED {
-		return &domain.PullRequestResponse{PR: *pr}, nil
+func (s *Service) getAuthor(ctx context.Context, pr *domain.PullRequest) (*domain.User, error) {
+	author, err := s.users.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
 	}
+	if author == nil {
+		return nil, domain.ErrAuthorNotFound
+	}
+	return author, nil
+}
 
-	mergedAt := time.Now()
-	pr.Status = domain.PullRequestStatusMERGED
-	pr.MergedAt = &mergedAt
-
-	if err := s.pr.Update(ctx, pr); err != nil {
-		return nil, err
+// prTeam returns the team whose settings and members apply to pr,
+// the author's primary team if the PR has none.
+func (s *Service) prTeam(ctx context.Context, pr *domain.PullRequest) (string, error) {
+	if pr.TeamName != "" {
+		return pr.TeamName, nil
 	}
 
-	return &domain.PullRequestResponse{PR: *pr}, nil
+	author, err := s.getAuthor(ctx, pr)
+	if err != nil {
+		return "", err
+	}
+	return author.TeamName, nil
 }
 
-func (s *Service) ReassignReviewer(ctx context.Context, prID, oldReviewerID string) (*domain.ReassignPRResponse, error) {
-	pr, err := s.validateReassignRequest(ctx, prID, oldReviewerID)
+// GetPullRequest returns pull request with username and team of every assigned reviewer.
+func (s *Service) GetPullRequest(ctx context.Context, prID string) (*domain.PullRequestResponse, error) {
+	pr, err := s.getPullRequest(ctx, prID)
 	if err != nil {
 		return nil, err
 	}
 
-	newReviewer, err := s.findReplacementReviewer(ctx, pr, oldReviewerID)
+	users, err := s.users.GetByIDs(ctx, pr.AssignedReviewers)
 	if err != nil {
 		return nil, err
 	}
+	byID := make(map[string]domain.User, len(users))
+	for _, u := range users {
+		byID[u.UserID] = u
+	}
+
+	pr.Reviewers = make([]domain.ReviewerInfo, 0, len(pr.AssignedReviewers))
+	for _, id := range pr.AssignedReviewers {
+		user, ok := byID[id]
+		if !ok {
+			return nil, domain.ErrUserNotFound
+		}
+
+		pr.Reviewers = append(pr.Reviewers, domain.ReviewerInfo{
+			UserID:   user.UserID,
+			Username: user.Username,
+			TeamName: user.TeamName,
+		})
+	}
+
+	return &domain.PullRequestResponse{PR: *pr}, nil
+}
+
+// ListPullRequests returns a page of pull requests, newest first unless sorted otherwise.
+func (s *Service) ListPullRequests(ctx context.Context,
+	params domain.GetPullRequestListParams) (*domain.PullRequestListResponse, error) {
+	switch params.SortBy {
+	case "":
+		params.SortBy = domain.PullRequestSortCreatedAt
+	case domain.PullRequestSortCreatedAt, domain.PullRequestSortName:
+	default:
+		return nil, domain.ErrInvalidListParams
+	}
+
+	if params.Limit == 0 {
+		params.Limit = domain.DefaultListLimit
+	}
+	if params.Limit < 0 || params.Limit > domain.MaxListLimit {
+		return nil, domain.ErrInvalidListParams
+	}
 
-	s.replaceReviewer(pr, oldReviewerID, newReviewer)
+	if params.Status != "" && !params.Status.IsValid() {
+		return nil, domain.ErrInvalidListParams
+	}
 
-	if err := s.pr.Update(ctx, pr); err != nil {
+	prs, next, err := s.pr.List(ctx, params)
+	if err != nil {
 		return nil, err
 	}
 
-	return &domain.ReassignPRResponse{
-		PR:         *pr,
-		ReplacedBy: newReviewer,
-	}, nil
+	return &domain.PullRequestListResponse{PullRequests: prs, NextCursor: next}, nil
+}
+
+// SubmitReview records decision of an assigned reviewer, the latest one per reviewer is current.
+func (s *Service) SubmitReview(ctx context.Context,
+	req *domain.PostPullRequestReviewJSONBody) (*domain.PullRequestResponse, error) {
+	if !req.Decision.IsValid() {
+		return nil, domain.ErrInvalidReviewDecision
+	}
+
+	return runInTx(ctx, s.tx, func(ctx context.Context) (*domain.PullRequestResponse, error) {
+		pr, err := s.getPullRequest(ctx, req.PullRequestID)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := checkReviewable(pr); err != nil {
+			return nil, err
+		}
+
+		if !s.isUserAssigned(pr, req.ReviewerID) {
+			return nil, domain.ErrUserNotReviewer
+		}
+
+		if err := s.pr.AddReviewDecision(ctx, pr.PullRequestID, req.ReviewerID, req.Decision); err != nil {
+			return nil, err
+		}
+
+		if err := s.pr.AppendEvents(ctx, pr.PullRequestID, domain.PREvent{
+			Type:       domain.PREventReviewSubmitted,
+			Actor:      req.ReviewerID,
+			ReviewerID: req.ReviewerID,
+			Details:    string(req.Decision),
+		}); err != nil {
+			return nil, err
+		}
+
+		pr, err = s.pr.GetByID(ctx, req.PullRequestID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &domain.PullRequestResponse{PR: *pr}, nil
+	})
+}
+
+func (s *Service) ReassignReviewer(ctx context.Context,
+	req *domain.PostPullRequestReassignJSONBody) (*domain.ReassignPRResponse, error) {
+	// system handovers are not limited, so the actor cannot be taken over by requests
+	if req.Actor == domain.ActorSystem {
+		return nil, domain.ErrReservedActor
+	}
+	return s.reassignReviewer(ctx, req.PullRequestID, req.OldUserID, req.Actor, "")
+}
+
+// reassignReviewer replaces oldReviewerID and records who triggered it and why in PR history.
+func (s *Service) reassignReviewer(ctx context.Context, prID, oldReviewerID, actor,
+	reason string) (*domain.ReassignPRResponse, error) {
+	return runInTx(ctx, s.tx, func(ctx context.Context) (*domain.ReassignPRResponse, error) {
+		// concurrent changes of the same pull request would otherwise overwrite each other's reviewers
+		if err := s.pr.Lock(ctx, prID); err != nil {
+			return nil, err
+		}
+
+		pr, err := s.validateReassignRequest(ctx, prID, oldReviewerID)
+		if err != nil {
+			return nil, err
+		}
+
+		// only reassignments requested by users are limited, handovers by the system always proceed
+		if s.maxReassignments > 0 && actor != domain.ActorSystem {
+			count, err := s.pr.CountUserEvents(ctx, prID, domain.PREventReviewerReassigned)
+			if err != nil {
+				return nil, err
+			}
+			if count >= s.maxReassignments {
+				return nil, domain.ErrReassignLimitReached
+			}
+		}
+
+		newReviewer, picked, err := s.findReplacementReviewer(ctx, pr, oldReviewerID)
+		if err != nil {
+			return nil, err
+		}
+
+		s.replaceReviewer(pr, oldReviewerID, newReviewer)
+
+		if err := s.pr.Update(ctx, pr); err != nil {
+			return nil, err
+		}
+
+		if err := s.pr.AppendEvents(ctx, prID, domain.PREvent{
+			Type:       domain.PREventReviewerReassigned,
+			Actor:      actor,
+			ReviewerID: oldReviewerID,
+			ReplacedBy: newReviewer,
+			Details:    reason,
+			Seed:       pr.AssignmentSeed,
+		}); err != nil {
+			return nil, err
+		}
+
+		// decisions of the replaced reviewer are dropped, read them back as stored
+		if pr, err = s.getPullRequest(ctx, prID); err != nil {
+			return nil, err
+		}
+
+		return &domain.ReassignPRResponse{
+			PR:                *pr,
+			ReplacedBy:        newReviewer,
+			FallbackReviewers: picked.fallback,
+			MaintainerMissing: picked.maintainerMissing,
+		}, nil
+	})
 }
 
 func (s *Service) validateReassignRequest(ctx context.Context, prID, oldReviewerID string) (*domain.PullRequest, error) {
@@ -116,8 +686,8 @@ func (s *Service) validateReassignRequest(ctx context.Context, prID, oldReviewer
 		return nil, domain.ErrPullRequestNotFound
 	}
 
-	if pr.Status == domain.PullRequestStatusMERGED {
-		return nil, domain.ErrChangeAfterMerge
+	if err := checkReviewable(pr); err != nil {
+		return nil, err
 	}
 
 	if !s.isUserAssigned(pr, oldReviewerID) {
@@ -127,6 +697,18 @@ func (s *Service) validateReassignRequest(ctx context.Context, prID, oldReviewer
 	return pr, nil
 }
 
+// checkReviewable allows reviews and reassignment only while the pull request is OPEN.
+func checkReviewable(pr *domain.PullRequest) error {
+	switch pr.Status {
+	case domain.PullRequestStatusOPEN:
+		return nil
+	case domain.PullRequestStatusMERGED:
+		return domain.ErrChangeAfterMerge
+	default:
+		return domain.ErrNotReviewable
+	}
+}
+
 func (s *Service) isUserAssigned(pr *domain.PullRequest, userID string) bool {
 	for _, id := range pr.AssignedReviewers {
 		if id == userID {
@@ -136,43 +718,77 @@ func (s *Service) isUserAssigned(pr *domain.PullRequest, userID string) bool {
 	return false
 }
 
-func (s *Service) findReplacementReviewer(ctx context.Context, pr *domain.PullRequest, oldReviewerID string) (string, error) {
+func (s *Service) findReplacementReviewer(ctx context.Context, pr *domain.PullRequest,
+	oldReviewerID string) (string, *assignment, error) {
 	oldReviewerUser, err := s.users.GetByID(ctx, oldReviewerID)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 	if oldReviewerUser == nil {
-		return "", domain.ErrUserNotFound
+		return "", nil, domain.ErrUserNotFound
+	}
+
+	// a reviewer from the PR's team is replaced from it, others from their primary team
+	teamName := oldReviewerUser.TeamName
+	if pr.TeamName != "" && oldReviewerUser.InTeam(pr.TeamName) {
+		teamName = pr.TeamName
 	}
 
-	candidates, err := s.getEligibleCandidates(ctx, pr, oldReviewerUser.TeamName, oldReviewerID)
+	settings, err := s.teamSettings(ctx, teamName)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	if len(candidates) == 0 {
-		return "", domain.ErrNoCandidatesFound
+	ownerGroups, err := s.codeOwnerGroups(ctx, pr.ChangedPaths)
+	if err != nil {
+		return "", nil, err
 	}
 
-	return selectRandomReviewers(candidates, 1)[0], nil
-}
+	exclude := s.getCurrentReviewersSet(pr)
+	exclude[pr.AuthorID] = true
+	exclude[oldReviewerID] = true
+	// former reviewers are skipped so that a review does not bounce back to them
+	for _, id := range pr.RemovedReviewers {
+		exclude[id] = true
+	}
 
-func (s *Service) getEligibleCandidates(ctx context.Context, pr *domain.PullRequest, teamName, oldReviewerID string) ([]string, error) {
-	teamMembers, err := s.users.GetByTeamActive(ctx, teamName)
+	req := assignmentRequest{
+		teamName:    teamName,
+		settings:    settings,
+		exclude:     exclude,
+		assigned:    remainingReviewers(pr, oldReviewerID),
+		ownerGroups: ownerGroups,
+		count:       1,
+		strategy:    priorityStrategy(pr.Priority),
+	}
+	seed, err := s.seedAssignment(ctx, pr.PullRequestID, &req)
 	if err != nil {
-		return nil, err
+		return "", nil, err
 	}
 
-	currentReviewers := s.getCurrentReviewersSet(pr)
-
-	var candidates []string
-	for _, m := range teamMembers {
-		if m.UserID != oldReviewerID && m.UserID != pr.AuthorID && !currentReviewers[m.UserID] {
-			candidates = append(candidates, m.UserID)
+	picked, err := s.pickReviewers(ctx, req)
+	if err != nil {
+		return "", nil, err
+	}
+	pr.AssignmentSeed = &seed
+	if len(picked.reviewers) == 0 {
+		if picked.atCapacity > 0 {
+			return "", nil, domain.ErrReviewersAtCapacity
 		}
+		return "", nil, domain.ErrNoCandidatesFound
 	}
 
-	return candidates, nil
+	return picked.reviewers[0], picked, nil
+}
+
+func remainingReviewers(pr *domain.PullRequest, removedID string) []string {
+	remaining := make([]string, 0, len(pr.AssignedReviewers))
+	for _, id := range pr.AssignedReviewers {
+		if id != removedID {
+			remaining = append(remaining, id)
+		}
+	}
+	return remaining
 }
 
 func (s *Service) getCurrentReviewersSet(pr *domain.PullRequest) map[string]bool {
@@ -183,6 +799,7 @@ func (s *Service) getCurrentReviewersSet(pr *domain.PullRequest) map[string]bool
 	return currentReviewersSet
 }
 
+// replaceReviewer swaps the reviewer in place, the old one is recorded as removed.
 func (s *Service) replaceReviewer(pr *domain.PullRequest, oldReviewerID, newReviewer string) {
 	for i, id := range pr.AssignedReviewers {
 		if id == oldReviewerID {
@@ -190,22 +807,5 @@ func (s *Service) replaceReviewer(pr *domain.PullRequest, oldReviewerID, newRevi
 			break
 		}
 	}
-}
-
-func selectRandomReviewers(candidates []string, maxCount int) []string {
-	if len(candidates) == 0 {
-		return []string{}
-	}
-
-	shuffled := make([]string, len(candidates))
-	copy(shuffled, candidates)
-
-	rand.Shuffle(len(shuffled), func(i, j int) {
-		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
-	})
-
-	if len(shuffled) < maxCount {
-		return shuffled
-	}
-	return shuffled[:maxCount]
+	pr.RemovedReviewers = append(pr.RemovedReviewers, oldReviewerID)
 }
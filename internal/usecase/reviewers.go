@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Egorrrad/avitotechBackendPR/internal/domain"
+)
+
+// AddReviewer assigns a specific active and available user to OPEN or DRAFT pull request,
+// the user may belong to any team. The author's team reviewer limit and the user's
+// max_open_reviews still apply.
+func (s *Service) AddReviewer(ctx context.Context,
+	req *domain.PostPullRequestAddReviewerJSONBody) (*domain.PullRequestResponse, error) {
+	return runInTx(ctx, s.tx, func(ctx context.Context) (*domain.PullRequestResponse, error) {
+		pr, user, teamName, err := s.editableReviewersPR(ctx, req.PullRequestID, req.UserID, req.Actor)
+		if err != nil {
+			return nil, err
+		}
+
+		if req.UserID == pr.AuthorID {
+			return nil, domain.ErrAuthorAsReviewer
+		}
+		if s.isUserAssigned(pr, req.UserID) {
+			return nil, domain.ErrAlreadyReviewer
+		}
+
+		available, err := s.users.GetActiveByIDs(ctx, []string{req.UserID})
+		if err != nil {
+			return nil, err
+		}
+		if len(available) == 0 {
+			return nil, domain.ErrReviewerUnavailable
+		}
+
+		settings, err := s.teamSettings(ctx, teamName)
+		if err != nil {
+			return nil, err
+		}
+		if len(pr.AssignedReviewers) >= settings.ReviewerLimit(pr.Priority) {
+			return nil, domain.ErrReviewerLimitReached
+		}
+
+		_, full, err := s.splitByCapacity(ctx, []domain.User{*user})
+		if err != nil {
+			return nil, err
+		}
+		if len(full) > 0 {
+			return nil, domain.ErrReviewersAtCapacity
+		}
+
+		pr.AssignedReviewers = append(pr.AssignedReviewers, req.UserID)
+		// a reviewer removed earlier may be added back by hand
+		pr.RemovedReviewers = excluding(pr.RemovedReviewers, []string{req.UserID})
+
+		return s.saveReviewers(ctx, pr, domain.PREvent{
+			Type:       domain.PREventReviewerAssigned,
+			Actor:      req.Actor,
+			ReviewerID: req.UserID,
+			Details:    "added manually",
+		})
+	})
+}
+
+// RemoveReviewer unassigns reviewer from OPEN or DRAFT pull request without picking a replacement.
+func (s *Service) RemoveReviewer(ctx context.Context,
+	req *domain.PostPullRequestRemoveReviewerJSONBody) (*domain.PullRequestResponse, error) {
+	return runInTx(ctx, s.tx, func(ctx context.Context) (*domain.PullRequestResponse, error) {
+		pr, _, _, err := s.editableReviewersPR(ctx, req.PullRequestID, req.UserID, req.Actor)
+		if err != nil {
+			return nil, err
+		}
+
+		if !s.isUserAssigned(pr, req.UserID) {
+			return nil, domain.ErrUserNotReviewer
+		}
+
+		pr.AssignedReviewers = remainingReviewers(pr, req.UserID)
+		pr.RemovedReviewers = append(pr.RemovedReviewers, req.UserID)
+
+		return s.saveReviewers(ctx, pr, domain.PREvent{
+			Type:       domain.PREventReviewerRemoved,
+			Actor:      req.Actor,
+			ReviewerID: req.UserID,
+			Details:    "removed manually",
+		})
+	})
+}
+
+// editableReviewersPR locks and returns pull request whose reviewers may be changed by hand with its team
+// after checking that both the pull request and the user exist. When reviewer edits are restricted,
+// actor must also be allowed to override rules of the team.
+func (s *Service) editableReviewersPR(ctx context.Context, prID, userID,
+	actor string) (*domain.PullRequest, *domain.User, string, error) {
+	// changes made by the service itself are told apart by this actor in PR history
+	if actor == domain.ActorSystem {
+		return nil, nil, "", domain.ErrReservedActor
+	}
+
+	if err := s.pr.Lock(ctx, prID); err != nil {
+		return nil, nil, "", err
+	}
+	pr, err := s.getPullRequest(ctx, prID)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	switch pr.Status {
+	case domain.PullRequestStatusMERGED:
+		return nil, nil, "", domain.ErrChangeAfterMerge
+	case domain.PullRequestStatusCLOSED:
+		return nil, nil, "", domain.ErrInvalidTransition
+	}
+
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if user == nil {
+		return nil, nil, "", domain.ErrUserNotFound
+	}
+
+	teamName, err := s.prTeam(ctx, pr)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if s.restrictReviewerEdits {
+		if err := s.authorizeActor(ctx, actor, teamName); err != nil {
+			return nil, nil, "", err
+		}
+	}
+
+	return pr, user, teamName, nil
+}
+
+func (s *Service) saveReviewers(ctx context.Context, pr *domain.PullRequest,
+	event domain.PREvent) (*domain.PullRequestResponse, error) {
+	if err := s.pr.Update(ctx, pr); err != nil {
+		return nil, err
+	}
+
+	if err := s.pr.AppendEvents(ctx, pr.PullRequestID, event); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.pr.GetByID(ctx, pr.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PullRequestResponse{PR: *pr}, nil
+}
+
+// excluding returns ids not present in skip, preserving order.
+func excluding(ids, skip []string) []string {
+	skipped := make(map[string]bool, len(skip))
+	for _, id := range skip {
+		skipped[id] = true
+	}
+
+	var rest []string
+	for _, id := range ids {
+		if !skipped[id] {
+			rest = append(rest, id)
+		}
+	}
+	return rest
+}
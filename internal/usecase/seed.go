@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"hash/fnv"
+	"math/rand"
+	"sort"
+)
+
+// SeedSource returns seeds for assignments outside of deterministic mode.
+type SeedSource func() int64
+
+// AssignmentSeed derives seed from pull request id and candidate set,
+// candidate order does not matter.
+func AssignmentSeed(prID string, candidates []string) int64 {
+	sorted := make([]string, len(candidates))
+	copy(sorted, candidates)
+	sort.Strings(sorted)
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(prID))
+	for _, id := range sorted {
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write([]byte(id))
+	}
+
+	return int64(h.Sum64()) //nolint:gosec // seed only needs the bits, overflow is fine
+}
+
+// seedAssignment picks the seed for a single assignment and sets the random source of req.
+// In deterministic mode the seed is a hash of the PR id and every candidate the assignment
+// may draw from: the team, its fallback teams, teams reached by hierarchy escalation and
+// code owners. RANDOM picks are then fully replayable from the seed and candidate set;
+// ROUND_ROBIN also depends on the team cursor and LEAST_LOADED and WEIGHTED_RANDOM on
+// open review counts at the time of assignment.
+func (s *Service) seedAssignment(ctx context.Context, prID string, req *assignmentRequest) (int64, error) {
+	seed := s.seeds()
+
+	if s.deterministic {
+		pool, err := s.candidatePool(ctx, *req)
+		if err != nil {
+			return 0, err
+		}
+		seed = AssignmentSeed(prID, pool)
+	}
+
+	req.rng = rand.New(rand.NewSource(seed)) //nolint:gosec // not used for security
+	return seed, nil
+}
+
+// candidatePool returns every eligible user the assignment described by req may pick.
+func (s *Service) candidatePool(ctx context.Context, req assignmentRequest) ([]string, error) {
+	teams := append([]string{req.teamName}, req.settings.FallbackTeams...)
+	if s.hierarchyEscalation && req.teamName != "" {
+		escalation, err := s.escalationTeams(ctx, req.teamName, teams)
+		if err != nil {
+			return nil, err
+		}
+		teams = append(teams, escalation...)
+	}
+
+	seen := make(map[string]bool)
+	var pool []string
+	add := func(id string) {
+		if !req.exclude[id] && !seen[id] {
+			seen[id] = true
+			pool = append(pool, id)
+		}
+	}
+
+	for _, team := range teams {
+		candidates, _, err := s.getEligibleCandidates(ctx, team, req.exclude)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range candidates {
+			add(id)
+		}
+	}
+	for _, group := range req.ownerGroups {
+		for _, id := range group {
+			add(id)
+		}
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssignmentSeed(t *testing.T) {
+	tests := []struct {
+		name     string
+		prA, prB string
+		a, b     []string
+		same     bool
+	}{
+		{name: "candidate order does not matter", prA: "pr-1", prB: "pr-1",
+			a: []string{"u1", "u2"}, b: []string{"u2", "u1"}, same: true},
+		{name: "pull request id changes seed", prA: "pr-1", prB: "pr-2",
+			a: []string{"u1", "u2"}, b: []string{"u1", "u2"}},
+		{name: "candidate set changes seed", prA: "pr-1", prB: "pr-1",
+			a: []string{"u1", "u2"}, b: []string{"u1", "u3"}},
+		{name: "ids are not concatenated", prA: "pr-1", prB: "pr-1",
+			a: []string{"u1", "u2"}, b: []string{"u1u2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := AssignmentSeed(tt.prA, tt.a), AssignmentSeed(tt.prB, tt.b)
+			if tt.same {
+				assert.Equal(t, a, b)
+			} else {
+				assert.NotEqual(t, a, b)
+			}
+		})
+	}
+}
+
+func TestAssignmentReplay(t *testing.T) {
+	// a RANDOM assignment is repeated exactly from its recorded seed and candidate set
+	candidates := []string{"u4", "u1", "u3", "u2"}
+	seed := AssignmentSeed("pr-1", candidates)
+
+	pick := func() []string {
+		picked, err := RandomSelector{}.Select(context.Background(), SelectionRequest{
+			Candidates: candidates,
+			Count:      2,
+			Rand:       rand.New(rand.NewSource(seed)),
+		})
+		require.NoError(t, err)
+		return picked
+	}
+
+	first := pick()
+	assert.Len(t, first, 2)
+	assert.Equal(t, first, pick())
+}
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/Egorrrad/avitotechBackendPR/internal/domain"
+)
+
+// ReviewerSelector picks reviewers for a pull request out of eligible candidates.
+type ReviewerSelector interface {
+	Select(ctx context.Context, req SelectionRequest) ([]string, error)
+}
+
+// SelectionRequest describes a single reviewer selection.
+type SelectionRequest struct {
+	// TeamName is the team whose members form the candidate pool
+	TeamName   string
+	Candidates []string
+	Count      int
+	// Rand is the only randomness source selectors may use, so that
+	// an assignment can be replayed from its seed
+	Rand *rand.Rand
+}
+
+type (
+	reviewerCursor interface {
+		AdvanceCursor(ctx context.Context, teamName string, step int) (int64, error)
+	}
+
+	reviewLoad interface {
+		CountOpenReviews(ctx context.Context, userIDs []string) (map[string]int, error)
+	}
+)
+
+func defaultSelectors(teams TeamRepo, pr PullRequestRepo) map[domain.ReviewerStrategy]ReviewerSelector {
+	return map[domain.ReviewerStrategy]ReviewerSelector{
+		domain.ReviewerStrategyRandom:         RandomSelector{},
+		domain.ReviewerStrategyRoundRobin:     NewRoundRobinSelector(teams),
+		domain.ReviewerStrategyLeastLoaded:    NewLeastLoadedSelector(pr),
+		domain.ReviewerStrategyWeightedRandom: NewWeightedRandomSelector(pr),
+	}
+}
+
+// RandomSelector picks reviewers uniformly at random.
+type RandomSelector struct{}
+
+func (RandomSelector) Select(_ context.Context, req SelectionRequest) ([]string, error) {
+	return selectRandomReviewers(req.Rand, req.Candidates, req.Count), nil
+}
+
+// RoundRobinSelector walks through the sorted candidates using a per-team cursor.
+type RoundRobinSelector struct {
+	cursor reviewerCursor
+}
+
+func NewRoundRobinSelector(cursor reviewerCursor) *RoundRobinSelector {
+	return &RoundRobinSelector{cursor: cursor}
+}
+
+func (s *RoundRobinSelector) Select(ctx context.Context, req SelectionRequest) ([]string, error) {
+	count := min(req.Count, len(req.Candidates))
+	if count <= 0 {
+		return []string{}, nil
+	}
+
+	sorted := make([]string, len(req.Candidates))
+	copy(sorted, req.Candidates)
+	sort.Strings(sorted)
+
+	position, err := s.cursor.AdvanceCursor(ctx, req.TeamName, count)
+	if err != nil {
+		return nil, err
+	}
+
+	start := int(position % int64(len(sorted)))
+
+	reviewers := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		reviewers = append(reviewers, sorted[(start+i)%len(sorted)])
+	}
+	return reviewers, nil
+}
+
+// LeastLoadedSelector picks candidates with the fewest open reviews,
+// ties are broken randomly.
+type LeastLoadedSelector struct {
+	load reviewLoad
+}
+
+func NewLeastLoadedSelector(load reviewLoad) *LeastLoadedSelector {
+	return &LeastLoadedSelector{load: load}
+}
+
+func (s *LeastLoadedSelector) Select(ctx context.Context, req SelectionRequest) ([]string, error) {
+	if len(req.Candidates) == 0 || req.Count <= 0 {
+		return []string{}, nil
+	}
+
+	loads, err := s.load.CountOpenReviews(ctx, req.Candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	// shuffle first so that stable sort breaks ties between equally loaded candidates randomly
+	sorted := selectRandomReviewers(req.Rand, req.Candidates, len(req.Candidates))
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return loads[sorted[i]] < loads[sorted[j]]
+	})
+
+	return sorted[:min(req.Count, len(sorted))], nil
+}
+
+// WeightedRandomSelector picks reviewers at random, favoring candidates with fewer open reviews.
+type WeightedRandomSelector struct {
+	load reviewLoad
+}
+
+func NewWeightedRandomSelector(load reviewLoad) *WeightedRandomSelector {
+	return &WeightedRandomSelector{load: load}
+}
+
+func (s *WeightedRandomSelector) Select(ctx context.Context, req SelectionRequest) ([]string, error) {
+	if len(req.Candidates) == 0 || req.Count <= 0 {
+		return []string{}, nil
+	}
+
+	loads, err := s.load.CountOpenReviews(ctx, req.Candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	// weighted sampling without replacement: each candidate gets key u^(1/w)
+	// and the candidates with the largest keys win
+	// candidates are visited in sorted order so that the same seed gives the same keys
+	sorted := make([]string, len(req.Candidates))
+	copy(sorted, req.Candidates)
+	sort.Strings(sorted)
+
+	keys := make(map[string]float64, len(sorted))
+	for _, id := range sorted {
+		weight := 1 / float64(loads[id]+1)
+		keys[id] = math.Pow(req.Rand.Float64(), 1/weight)
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return keys[sorted[i]] > keys[sorted[j]]
+	})
+
+	return sorted[:min(req.Count, len(sorted))], nil
+}
+
+func selectRandomReviewers(rng *rand.Rand, candidates []string, maxCount int) []string {
+	if len(candidates) == 0 {
+		return []string{}
+	}
+
+	// sort before shuffling: candidate order coming from storage is not stable
+	shuffled := make([]string, len(candidates))
+	copy(shuffled, candidates)
+	sort.Strings(shuffled)
+
+	rng.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+
+	if len(shuffled) < maxCount {
+		return shuffled
+	}
+	return shuffled[:maxCount]
+}
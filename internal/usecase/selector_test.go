@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCursor struct {
+	position int64
+	steps    []int
+}
+
+func (c *fakeCursor) AdvanceCursor(_ context.Context, _ string, step int) (int64, error) {
+	c.steps = append(c.steps, step)
+	return c.position, nil
+}
+
+type fakeLoad map[string]int
+
+func (l fakeLoad) CountOpenReviews(_ context.Context, _ []string) (map[string]int, error) {
+	return l, nil
+}
+
+var testCandidates = []string{"u1", "u2", "u3", "u4", "u5"}
+
+func TestRandomSelector(t *testing.T) {
+	tests := []struct {
+		name       string
+		candidates []string
+		count      int
+		seed       int64
+		want       []string
+	}{
+		{name: "picks count reviewers", candidates: testCandidates, count: 2, seed: 42, want: []string{"u3", "u4"}},
+		{name: "candidate order does not matter",
+			candidates: []string{"u5", "u4", "u3", "u2", "u1"}, count: 2, seed: 42, want: []string{"u3", "u4"}},
+		{name: "other seed gives other reviewers", candidates: testCandidates, count: 2, seed: 7,
+			want: []string{"u3", "u2"}},
+		{name: "count above candidates returns all", candidates: testCandidates, count: 7, seed: 42,
+			want: []string{"u3", "u4", "u5", "u1", "u2"}},
+		{name: "no candidates", candidates: nil, count: 2, seed: 42, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RandomSelector{}.Select(context.Background(), SelectionRequest{
+				Candidates: tt.candidates,
+				Count:      tt.count,
+				Rand:       rand.New(rand.NewSource(tt.seed)),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRoundRobinSelector(t *testing.T) {
+	tests := []struct {
+		name       string
+		candidates []string
+		count      int
+		position   int64
+		want       []string
+		wantSteps  []int
+	}{
+		{name: "starts at cursor", candidates: testCandidates, count: 2, position: 1,
+			want: []string{"u2", "u3"}, wantSteps: []int{2}},
+		{name: "wraps around", candidates: testCandidates, count: 2, position: 9,
+			want: []string{"u5", "u1"}, wantSteps: []int{2}},
+		{name: "walks sorted candidates", candidates: []string{"u3", "u1", "u2"}, count: 3, position: 0,
+			want: []string{"u1", "u2", "u3"}, wantSteps: []int{3}},
+		{name: "count capped by candidates", candidates: []string{"u1", "u2"}, count: 5, position: 0,
+			want: []string{"u1", "u2"}, wantSteps: []int{2}},
+		{name: "no candidates keeps cursor", candidates: nil, count: 2, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor := &fakeCursor{position: tt.position}
+			got, err := NewRoundRobinSelector(cursor).Select(context.Background(), SelectionRequest{
+				TeamName:   "backend",
+				Candidates: tt.candidates,
+				Count:      tt.count,
+				Rand:       rand.New(rand.NewSource(42)),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantSteps, cursor.steps)
+		})
+	}
+}
+
+func TestLeastLoadedSelector(t *testing.T) {
+	load := fakeLoad{"u1": 3, "u2": 0, "u3": 1, "u4": 0, "u5": 2}
+
+	tests := []struct {
+		name  string
+		count int
+		seed  int64
+		want  []string
+	}{
+		{name: "least loaded first", count: 3, seed: 42, want: []string{"u4", "u2", "u3"}},
+		{name: "seed breaks ties", count: 3, seed: 1, want: []string{"u2", "u4", "u3"}},
+		{name: "single reviewer", count: 1, seed: 42, want: []string{"u4"}},
+		{name: "zero count", count: 0, seed: 42, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewLeastLoadedSelector(load).Select(context.Background(), SelectionRequest{
+				Candidates: testCandidates,
+				Count:      tt.count,
+				Rand:       rand.New(rand.NewSource(tt.seed)),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWeightedRandomSelector(t *testing.T) {
+	load := fakeLoad{"u1": 3, "u2": 0, "u3": 1, "u4": 0, "u5": 2}
+
+	tests := []struct {
+		name       string
+		candidates []string
+		count      int
+		want       []string
+	}{
+		{name: "picks count reviewers", candidates: testCandidates, count: 2, want: []string{"u3", "u4"}},
+		{name: "candidate order does not matter",
+			candidates: []string{"u5", "u4", "u3", "u2", "u1"}, count: 3, want: []string{"u3", "u4", "u2"}},
+		{name: "no candidates", candidates: nil, count: 2, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewWeightedRandomSelector(load).Select(context.Background(), SelectionRequest{
+				Candidates: tt.candidates,
+				Count:      tt.count,
+				Rand:       rand.New(rand.NewSource(42)),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Egorrrad/avitotechBackendPR/internal/domain"
+)
+
+const (
+	reasonDeactivated = "reviewer deactivated"
+	reasonStale       = "review stale"
+	reasonLeftTeam    = "reviewer removed from team"
+)
+
+// HandleStaleReviews flags reviewers of OPEN pull requests who have not acted since before
+// and applies the stale review policy of the author's team. Every pull request is handled in
+// its own transaction under a row lock, so concurrent runs skip it and see the result afterwards.
+// In dry run nothing is written and the returned reviews carry the action that would be applied.
+func (s *Service) HandleStaleReviews(ctx context.Context, before time.Time, dryRun bool) ([]domain.StaleReview, error) {
+	return s.handleStaleReviews(ctx, before, "", dryRun)
+}
+
+// ScanStaleReviews handles stale reviews on demand, optionally of a single pull request,
+// the same way as the background worker does.
+func (s *Service) ScanStaleReviews(ctx context.Context,
+	req *domain.PostPullRequestScanStaleJSONBody) (*domain.StaleReviewsResponse, error) {
+	if req.Before.IsZero() {
+		return nil, domain.ErrStaleScanBefore
+	}
+
+	reviews, err := s.handleStaleReviews(ctx, req.Before, req.PullRequestID, req.DryRun)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.StaleReviewsResponse{StaleReviews: reviews}, nil
+}
+
+func (s *Service) handleStaleReviews(ctx context.Context, before time.Time, prID string,
+	dryRun bool) ([]domain.StaleReview, error) {
+	candidates, err := s.pr.GetStaleReviews(ctx, before, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	if dryRun {
+		return s.planStaleReviews(ctx, candidates)
+	}
+
+	var prIDs []string
+	seen := make(map[string]bool)
+	for _, c := range candidates {
+		if !seen[c.PullRequestID] {
+			seen[c.PullRequestID] = true
+			prIDs = append(prIDs, c.PullRequestID)
+		}
+	}
+
+	handled := make([]domain.StaleReview, 0, len(candidates))
+	var errs []error
+	for _, prID := range prIDs {
+		reviews, err := runInTx(ctx, s.tx, func(ctx context.Context) ([]domain.StaleReview, error) {
+			return s.handlePullRequestStaleReviews(ctx, prID, before)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("pull request %s: %w", prID, err))
+			continue
+		}
+		handled = append(handled, reviews...)
+	}
+
+	return handled, errors.Join(errs...)
+}
+
+func (s *Service) planStaleReviews(ctx context.Context, candidates []domain.StaleReview) ([]domain.StaleReview, error) {
+	for i := range candidates {
+		c := &candidates[i]
+		pr, teamName, policy, err := s.stalePolicy(ctx, c.PullRequestID)
+		if err != nil {
+			return nil, err
+		}
+
+		c.Action = policy.Action
+		if policy.Action == domain.StaleReviewActionEscalate {
+			if c.Target, err = s.findLead(ctx, pr, teamName, c.ReviewerID); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return candidates, nil
+}
+
+// handlePullRequestStaleReviews must run in a transaction, stale reviewers are looked up
+// again after the lock is taken because another replica may have just handled them.
+func (s *Service) handlePullRequestStaleReviews(ctx context.Context, prID string,
+	before time.Time) ([]domain.StaleReview, error) {
+	locked, err := s.pr.LockOpen(ctx, prID)
+	if err != nil || !locked {
+		return nil, err
+	}
+
+	reviews, err := s.pr.GetStaleReviews(ctx, before, prID)
+	if err != nil || len(reviews) == 0 {
+		return nil, err
+	}
+
+	_, teamName, policy, err := s.stalePolicy(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range reviews {
+		if err := s.handleStaleReview(ctx, &reviews[i], teamName, policy); err != nil {
+			return nil, err
+		}
+	}
+	return reviews, nil
+}
+
+func (s *Service) handleStaleReview(ctx context.Context, review *domain.StaleReview, teamName string,
+	policy domain.StaleReviewPolicy) error {
+	review.Action = policy.Action
+
+	event := domain.PREvent{
+		Type:       domain.PREventReviewStale,
+		Actor:      domain.ActorSystem,
+		ReviewerID: review.ReviewerID,
+		Details:    "no activity since " + review.LastActivityAt.UTC().Format(time.RFC3339),
+	}
+
+	switch policy.Action {
+	case domain.StaleReviewActionReassign:
+		newReviewer, err := s.tryReassign(ctx, review.PullRequestID, review.ReviewerID, reasonStale)
+		if err != nil {
+			return err
+		}
+		if newReviewer == "" {
+			event.Details += ", no replacement available"
+			break
+		}
+		review.Target = newReviewer
+		event.Details += ", reassigned to " + newReviewer
+	case domain.StaleReviewActionEscalate:
+		lead, err := s.escalateStaleReview(ctx, review.PullRequestID, teamName, review.ReviewerID)
+		if err != nil {
+			return err
+		}
+		if lead == "" {
+			event.Details += ", no lead available"
+			break
+		}
+		review.Target = lead
+		event.Details += ", escalated to " + lead
+	}
+
+	return s.pr.AppendEvents(ctx, review.PullRequestID, event)
+}
+
+// escalateStaleReview assigns a lead of the team to the pull request as an extra reviewer,
+// regardless of the reviewer limit and max_open_reviews. A lead already reviewing it is kept.
+// Returns the lead, empty if no lead is available.
+func (s *Service) escalateStaleReview(ctx context.Context, prID, teamName, staleReviewerID string) (string, error) {
+	pr, err := s.getPullRequest(ctx, prID)
+	if err != nil {
+		return "", err
+	}
+
+	lead, err := s.findLead(ctx, pr, teamName, staleReviewerID)
+	if err != nil || lead == "" || s.isUserAssigned(pr, lead) {
+		return lead, err
+	}
+
+	pr.AssignedReviewers = append(pr.AssignedReviewers, lead)
+	if err := s.pr.Update(ctx, pr); err != nil {
+		return "", err
+	}
+
+	return lead, s.pr.AppendEvents(ctx, prID, domain.PREvent{
+		Type:       domain.PREventReviewerAssigned,
+		Actor:      domain.ActorSystem,
+		ReviewerID: lead,
+		Details:    "escalated stale review of " + staleReviewerID,
+	})
+}
+
+// findLead returns an active and available LEAD of the team who is neither the author
+// nor the stale reviewer, a lead already reviewing the pull request is preferred.
+func (s *Service) findLead(ctx context.Context, pr *domain.PullRequest, teamName,
+	staleReviewerID string) (string, error) {
+	if teamName == "" {
+		return "", nil
+	}
+
+	leadIDs, err := s.teams.GetMembersByRole(ctx, teamName, domain.TeamRoleLead)
+	if err != nil {
+		return "", err
+	}
+	leads, err := s.users.GetActiveByIDs(ctx, leadIDs)
+	if err != nil {
+		return "", err
+	}
+
+	var found string
+	for _, lead := range leads {
+		if lead.UserID == pr.AuthorID || lead.UserID == staleReviewerID {
+			continue
+		}
+		if s.isUserAssigned(pr, lead.UserID) {
+			return lead.UserID, nil
+		}
+		if found == "" {
+			found = lead.UserID
+		}
+	}
+	return found, nil
+}
+
+// stalePolicy returns the pull request with its team and the team's stale review policy.
+func (s *Service) stalePolicy(ctx context.Context, prID string) (*domain.PullRequest, string,
+	domain.StaleReviewPolicy, error) {
+	pr, err := s.getPullRequest(ctx, prID)
+	if err != nil {
+		return nil, "", domain.StaleReviewPolicy{}, err
+	}
+
+	teamName, err := s.prTeam(ctx, pr)
+	if err != nil {
+		return nil, "", domain.StaleReviewPolicy{}, err
+	}
+
+	settings, err := s.teamSettings(ctx, teamName)
+	if err != nil {
+		return nil, "", domain.StaleReviewPolicy{}, err
+	}
+	return pr, teamName, settings.StaleReview, nil
+}
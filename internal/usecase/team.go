@@ -2,11 +2,17 @@ package usecase
 
 import (
 	"context"
+	"strings"
 
 	"github.com/Egorrrad/avitotechBackendPR/internal/domain"
 )
 
-func (s *Service) CreateTeam(ctx context.Context, teamName string, members []domain.TeamMember) (*domain.Team, error) {
+func (s *Service) CreateTeam(ctx context.Context, teamName string, members []domain.TeamMember,
+	settings domain.TeamSettings, parentTeam string) (*domain.Team, error) {
+	if err := s.validateTeamSettings(ctx, teamName, &settings); err != nil {
+		return nil, err
+	}
+
 	exists, err := s.teams.Exists(ctx, teamName)
 	if err != nil {
 		return nil, err
@@ -15,30 +21,247 @@ func (s *Service) CreateTeam(ctx context.Context, teamName string, members []dom
 		return nil, domain.ErrTeamAlreadyExists
 	}
 
-	domainUsers := make([]domain.User, 0, len(members))
-	for _, m := range members {
-		domainUsers = append(domainUsers, domain.User{
-			UserID:   m.UserID,
-			Username: m.Username,
-			IsActive: m.IsActive,
-			TeamName: teamName,
-		})
+	if parentTeam != "" {
+		exists, err := s.teams.Exists(ctx, parentTeam)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, domain.ErrTeamNotFound
+		}
+	}
+
+	domainUsers, err := membersToUsers(teamName, members)
+	if err != nil {
+		return nil, err
 	}
 
 	team := &domain.Team{
-		TeamName: teamName,
-		Members:  members,
+		TeamName:   teamName,
+		ParentTeam: parentTeam,
+		Members:    members,
+		Settings:   settings,
 	}
 
-	if err := s.teams.Create(ctx, team); err != nil {
+	err = s.tx.RunInTx(ctx, func(ctx context.Context) error {
+		if err := s.teams.Create(ctx, team); err != nil {
+			return err
+		}
+		return s.saveMembers(ctx, teamName, members, domainUsers)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	if err := s.users.UpsertBatch(ctx, domainUsers); err != nil {
+	return team, nil
+}
+
+// AddTeamMembers creates or updates users and makes them members of an existing team,
+// their other memberships are kept.
+func (s *Service) AddTeamMembers(ctx context.Context, req *domain.PostTeamAddMemberJSONBody) (*domain.Team, error) {
+	domainUsers, err := membersToUsers(req.TeamName, req.Members)
+	if err != nil {
 		return nil, err
 	}
 
-	return team, nil
+	return runInTx(ctx, s.tx, func(ctx context.Context) (*domain.Team, error) {
+		exists, err := s.teams.Exists(ctx, req.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, domain.ErrTeamNotFound
+		}
+
+		if err := s.saveMembers(ctx, req.TeamName, req.Members, domainUsers); err != nil {
+			return nil, err
+		}
+		return s.GetTeam(ctx, req.TeamName)
+	})
+}
+
+// RemoveTeamMember removes user from the team, the user itself is kept.
+// Open reviews are handed over first, while the user's teammates are still candidates;
+// a user staying in other teams hands over only reviews of the team's pull requests.
+func (s *Service) RemoveTeamMember(ctx context.Context,
+	req *domain.PostTeamRemoveMemberJSONBody) (*domain.TeamMemberRemovedResponse, error) {
+	reassign := s.reassignOnDeactivate
+	if req.ReassignReviews != nil {
+		reassign = *req.ReassignReviews
+	}
+
+	return runInTx(ctx, s.tx, func(ctx context.Context) (*domain.TeamMemberRemovedResponse, error) {
+		exists, err := s.teams.Exists(ctx, req.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, domain.ErrTeamNotFound
+		}
+
+		user, err := s.users.GetByID(ctx, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, domain.ErrUserNotFound
+		}
+		if !user.InTeam(req.TeamName) {
+			return nil, domain.ErrNotTeamMember
+		}
+
+		// a member of other teams keeps reviews outside the team being left
+		scope := ""
+		if len(user.Teams) > 1 {
+			scope = req.TeamName
+		}
+
+		resp := &domain.TeamMemberRemovedResponse{}
+		if reassign {
+			resp.Reassigned, resp.NotReassigned, err = s.handOverReviews(ctx, user.UserID, scope, reasonLeftTeam)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if err := s.teams.RemoveMember(ctx, req.TeamName, req.UserID); err != nil {
+			return nil, err
+		}
+
+		team, err := s.GetTeam(ctx, req.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		resp.Team = *team
+		return resp, nil
+	})
+}
+
+// RenameTeam changes team name, members, settings and code owner rules follow the team.
+func (s *Service) RenameTeam(ctx context.Context, req *domain.PostTeamRenameJSONBody) (*domain.Team, error) {
+	if req.NewTeamName == "" {
+		return nil, domain.ErrEmptyTeamName
+	}
+
+	return runInTx(ctx, s.tx, func(ctx context.Context) (*domain.Team, error) {
+		if err := s.teams.Rename(ctx, req.TeamName, req.NewTeamName); err != nil {
+			return nil, err
+		}
+		return s.GetTeam(ctx, req.NewTeamName)
+	})
+}
+
+// DeleteTeam deletes a team that has no members or is archived,
+// members of an archived team stay in their other teams.
+func (s *Service) DeleteTeam(ctx context.Context, req *domain.PostTeamDeleteJSONBody) (*domain.TeamDeletedResponse, error) {
+	return runInTx(ctx, s.tx, func(ctx context.Context) (*domain.TeamDeletedResponse, error) {
+		team, err := s.GetTeam(ctx, req.TeamName)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(team.Members) > 0 && !team.Archived {
+			return nil, domain.ErrTeamNotEmpty
+		}
+
+		if err := s.teams.Delete(ctx, req.TeamName); err != nil {
+			return nil, err
+		}
+		return &domain.TeamDeletedResponse{TeamName: req.TeamName}, nil
+	})
+}
+
+// SetTeamParent moves the team under another one, empty parent makes it top-level.
+func (s *Service) SetTeamParent(ctx context.Context, req *domain.PostTeamSetParentJSONBody) (*domain.Team, error) {
+	if req.ParentTeam == req.TeamName {
+		return nil, domain.ErrTeamCycle
+	}
+
+	return runInTx(ctx, s.tx, func(ctx context.Context) (*domain.Team, error) {
+		if err := s.teams.SetParent(ctx, req.TeamName, req.ParentTeam); err != nil {
+			return nil, err
+		}
+		return s.GetTeam(ctx, req.TeamName)
+	})
+}
+
+// SetTeamArchived marks the team archived or brings it back in use.
+func (s *Service) SetTeamArchived(ctx context.Context, req *domain.PostTeamSetArchivedJSONBody) (*domain.Team, error) {
+	return runInTx(ctx, s.tx, func(ctx context.Context) (*domain.Team, error) {
+		if err := s.teams.SetArchived(ctx, req.TeamName, req.Archived); err != nil {
+			return nil, err
+		}
+		return s.GetTeam(ctx, req.TeamName)
+	})
+}
+
+// GetTeamSubtree returns the team with all teams nested in it.
+func (s *Service) GetTeamSubtree(ctx context.Context, teamName string) (*domain.TeamTree, error) {
+	return s.teams.GetSubtree(ctx, teamName)
+}
+
+// GetTeamAncestors returns teams the team is nested in, parent first.
+func (s *Service) GetTeamAncestors(ctx context.Context, teamName string) (*domain.TeamAncestorsResponse, error) {
+	exists, err := s.teams.Exists(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, domain.ErrTeamNotFound
+	}
+
+	ancestors, err := s.teams.GetAncestors(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.TeamAncestorsResponse{TeamName: teamName, Ancestors: ancestors}, nil
+}
+
+// membersToUsers validates members and normalizes their roles in place.
+func membersToUsers(teamName string, members []domain.TeamMember) ([]domain.User, error) {
+	users := make([]domain.User, 0, len(members))
+	for i, m := range members {
+		if err := validateReviewCapacity(m.MaxOpenReviews); err != nil {
+			return nil, err
+		}
+
+		role, err := parseTeamRole(m.Role)
+		if err != nil {
+			return nil, err
+		}
+		members[i].Role = role
+
+		users = append(users, domain.User{
+			UserID:         m.UserID,
+			Username:       m.Username,
+			IsActive:       m.IsActive,
+			MaxOpenReviews: m.MaxOpenReviews,
+			TeamName:       teamName,
+		})
+	}
+	return users, nil
+}
+
+// parseTeamRole accepts role in any case, empty means MEMBER.
+func parseTeamRole(r domain.TeamRole) (domain.TeamRole, error) {
+	if r == "" {
+		return domain.TeamRoleMember, nil
+	}
+
+	role := domain.TeamRole(strings.ToUpper(string(r)))
+	if !role.IsValid() {
+		return "", domain.ErrInvalidRole
+	}
+	return role, nil
+}
+
+// saveMembers upserts users and links them to the team with their roles.
+func (s *Service) saveMembers(ctx context.Context, teamName string, members []domain.TeamMember,
+	users []domain.User) error {
+	if err := s.users.UpsertBatch(ctx, users); err != nil {
+		return err
+	}
+	return s.teams.AddMembers(ctx, teamName, members)
 }
 
 func (s *Service) GetTeam(ctx context.Context, teamName string) (*domain.Team, error) {
@@ -51,3 +274,47 @@ func (s *Service) GetTeam(ctx context.Context, teamName string) (*domain.Team, e
 	}
 	return team, nil
 }
+
+func (s *Service) UpdateTeamSettings(ctx context.Context, teamName string,
+	patch domain.TeamSettingsPatch) (*domain.Team, error) {
+	err := s.tx.RunInTx(ctx, func(ctx context.Context) error {
+		settings, err := s.teams.GetSettings(ctx, teamName)
+		if err != nil {
+			return err
+		}
+
+		patch.Apply(settings)
+		if err := s.validateTeamSettings(ctx, teamName, settings); err != nil {
+			return err
+		}
+
+		return s.teams.UpdateSettings(ctx, teamName, settings)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetTeam(ctx, teamName)
+}
+
+func (s *Service) validateTeamSettings(ctx context.Context, teamName string, settings *domain.TeamSettings) error {
+	settings.Normalize()
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+
+	for _, fallback := range settings.FallbackTeams {
+		if fallback == teamName {
+			return domain.ErrInvalidTeamSettings
+		}
+
+		exists, err := s.teams.Exists(ctx, fallback)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return domain.ErrTeamNotFound
+		}
+	}
+	return nil
+}
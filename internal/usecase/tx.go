@@ -0,0 +1,18 @@
+package usecase
+
+import "context"
+
+// runInTx runs fn in a transaction and returns its result, nested calls join the outer transaction.
+func runInTx[T any](ctx context.Context, tx TxManager, fn func(ctx context.Context) (T, error)) (T, error) {
+	var result T
+	err := tx.RunInTx(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = fn(ctx)
+		return err
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return result, nil
+}
@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"math/rand"
+	"time"
 
 	"github.com/Egorrrad/avitotechBackendPR/internal/domain"
 )
@@ -13,32 +15,110 @@ type (
 		Update(ctx context.Context, pr *domain.PullRequest) error
 		GetByReviewerID(ctx context.Context, userID string) ([]*domain.PullRequestShort, error)
 		Exists(ctx context.Context, id string) (bool, error)
+		Lock(ctx context.Context, id string) error
+		CountOpenReviews(ctx context.Context, userIDs []string) (map[string]int, error)
+		AddReviewDecision(ctx context.Context, prID, userID string, decision domain.ReviewDecision) error
+		RecordForcedMerge(ctx context.Context, prID, actor string, missing []string) error
+		List(ctx context.Context, params domain.GetPullRequestListParams) ([]*domain.PullRequest, string, error)
+		AppendEvents(ctx context.Context, prID string, events ...domain.PREvent) error
+		GetEvents(ctx context.Context, prID string) ([]domain.PREvent, error)
+		CountUserEvents(ctx context.Context, prID string, eventType domain.PREventType) (int, error)
+		GetStaleReviews(ctx context.Context, before time.Time, prID string) ([]domain.StaleReview, error)
+		LockOpen(ctx context.Context, prID string) (bool, error)
 	}
 
 	TeamRepo interface {
 		Create(ctx context.Context, team *domain.Team) error
 		GetByName(ctx context.Context, name string) (*domain.Team, error)
 		Exists(ctx context.Context, name string) (bool, error)
+		GetSettings(ctx context.Context, name string) (*domain.TeamSettings, error)
+		UpdateSettings(ctx context.Context, name string, settings *domain.TeamSettings) error
+		AdvanceCursor(ctx context.Context, name string, step int) (int64, error)
+		AddMembers(ctx context.Context, name string, members []domain.TeamMember) error
+		GetMembersByRole(ctx context.Context, name string, roles ...domain.TeamRole) ([]string, error)
+		RemoveMember(ctx context.Context, name, userID string) error
+		SetPrimary(ctx context.Context, name, userID string) error
+		SetParent(ctx context.Context, name, parent string) error
+		SetArchived(ctx context.Context, name string, archived bool) error
+		GetAncestors(ctx context.Context, name string) ([]string, error)
+		GetChildren(ctx context.Context, name string) ([]string, error)
+		GetSubtree(ctx context.Context, name string) (*domain.TeamTree, error)
+		Rename(ctx context.Context, name, newName string) error
+		Delete(ctx context.Context, name string) error
 	}
 
 	UserRepo interface {
 		UpsertBatch(ctx context.Context, users []domain.User) error
 		GetByID(ctx context.Context, id string) (*domain.User, error)
+		GetByIDs(ctx context.Context, ids []string) ([]domain.User, error)
 		Update(ctx context.Context, user *domain.User) error
 		GetByTeamActive(ctx context.Context, teamName string) ([]domain.User, error)
+		GetActiveByIDs(ctx context.Context, ids []string) ([]domain.User, error)
+		List(ctx context.Context, params domain.GetUsersListParams) ([]domain.User, string, error)
+	}
+
+	UnavailabilityRepo interface {
+		Create(ctx context.Context, period *domain.Unavailability) error
+		GetByID(ctx context.Context, id int64) (*domain.Unavailability, error)
+		GetByUserID(ctx context.Context, userID string) ([]domain.Unavailability, error)
+		Update(ctx context.Context, period *domain.Unavailability) error
+		Delete(ctx context.Context, id int64) error
+	}
+
+	TxManager interface {
+		RunInTx(ctx context.Context, fn func(ctx context.Context) error) error
+	}
+
+	CodeOwnerRepo interface {
+		List(ctx context.Context) ([]domain.CodeOwnerRule, error)
+		Replace(ctx context.Context, rules []domain.CodeOwnerRule) error
 	}
 )
 
 type Service struct {
-	teams TeamRepo
-	users UserRepo
-	pr    PullRequestRepo
+	teams        TeamRepo
+	users        UserRepo
+	pr           PullRequestRepo
+	owners       CodeOwnerRepo
+	availability UnavailabilityRepo
+	tx           TxManager
+
+	selectors       map[domain.ReviewerStrategy]ReviewerSelector
+	defaultStrategy domain.ReviewerStrategy
+
+	seeds         SeedSource
+	deterministic bool
+
+	reassignOnDeactivate bool
+	// maxReassignments per pull request, 0 means unlimited
+	maxReassignments int
+	// hierarchyEscalation lets assignment take siblings and parent teams when a team runs out of candidates
+	hierarchyEscalation bool
+	// admins may override team rules in any team
+	admins map[string]bool
+	// restrictReviewerEdits requires a privileged actor to add or remove reviewers by hand
+	restrictReviewerEdits bool
 }
 
-func NewService(team TeamRepo, users UserRepo, pr PullRequestRepo) *Service {
-	return &Service{
-		teams: team,
-		users: users,
-		pr:    pr,
+func NewService(team TeamRepo, users UserRepo, pr PullRequestRepo, owners CodeOwnerRepo,
+	availability UnavailabilityRepo, tx TxManager, opts ...Option) *Service {
+	s := &Service{
+		teams:           team,
+		users:           users,
+		pr:              pr,
+		owners:          owners,
+		availability:    availability,
+		tx:              tx,
+		selectors:       defaultSelectors(team, pr),
+		defaultStrategy: domain.ReviewerStrategyRandom,
+		seeds:           rand.Int63,
+		admins:          make(map[string]bool),
 	}
+
+	// Custom options
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
@@ -2,11 +2,51 @@ package usecase
 
 import (
 	"context"
+	"errors"
 
 	"github.com/Egorrrad/avitotechBackendPR/internal/domain"
 )
 
-func (s *Service) UpdateUserActive(ctx context.Context, userID string, active bool) (*domain.UserUpdActiveResponse, error) {
+func (s *Service) UpdateUserActive(ctx context.Context,
+	req *domain.PostUsersSetIsActiveJSONBody) (*domain.UserUpdActiveResponse, error) {
+	reassign := s.reassignOnDeactivate
+	if req.ReassignReviews != nil {
+		reassign = *req.ReassignReviews
+	}
+
+	var resp *domain.UserUpdActiveResponse
+	err := s.tx.RunInTx(ctx, func(ctx context.Context) error {
+		user, err := s.users.GetByID(ctx, req.UserID)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return domain.ErrUserNotFound
+		}
+
+		user.IsActive = req.IsActive
+
+		if err := s.users.Update(ctx, user); err != nil {
+			return err
+		}
+
+		resp = &domain.UserUpdActiveResponse{User: *user}
+		if req.IsActive || !reassign {
+			return nil
+		}
+
+		resp.Reassigned, resp.NotReassigned, err = s.handOverReviews(ctx, user.UserID, "", reasonDeactivated)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetUser returns user with all their teams and the number of OPEN pull requests they review.
+func (s *Service) GetUser(ctx context.Context, userID string) (*domain.UserDetailsResponse, error) {
 	user, err := s.users.GetByID(ctx, userID)
 	if err != nil {
 		return nil, err
@@ -15,13 +55,257 @@ func (s *Service) UpdateUserActive(ctx context.Context, userID string, active bo
 		return nil, domain.ErrUserNotFound
 	}
 
-	user.IsActive = active
+	load, err := s.pr.CountOpenReviews(ctx, []string{user.UserID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.UserDetailsResponse{User: *user, OpenReviews: load[user.UserID]}, nil
+}
+
+// ListUsers returns a page of users matching params.
+func (s *Service) ListUsers(ctx context.Context, params domain.GetUsersListParams) (*domain.UserListResponse, error) {
+	if params.Limit == 0 {
+		params.Limit = domain.DefaultListLimit
+	}
+	if params.Limit < 0 || params.Limit > domain.MaxListLimit {
+		return nil, domain.ErrInvalidListParams
+	}
+
+	users, next, err := s.users.List(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.UserListResponse{Users: users, NextCursor: next}, nil
+}
+
+// SetMaxOpenReviews sets user's review capacity, nil removes the limit.
+// Reviews already assigned above the new limit are kept.
+func (s *Service) SetMaxOpenReviews(ctx context.Context,
+	req *domain.PostUsersSetMaxOpenReviewsJSONBody) (*domain.UserResponse, error) {
+	if err := validateReviewCapacity(req.MaxOpenReviews); err != nil {
+		return nil, err
+	}
+
+	user, err := s.users.GetByID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	user.MaxOpenReviews = req.MaxOpenReviews
 
 	if err := s.users.Update(ctx, user); err != nil {
 		return nil, err
 	}
 
-	return &domain.UserUpdActiveResponse{User: *user}, nil
+	return &domain.UserResponse{User: *user}, nil
+}
+
+// SetPrimaryTeam makes one of the user's teams primary.
+func (s *Service) SetPrimaryTeam(ctx context.Context, req *domain.PostUsersSetPrimaryTeamJSONBody) (*domain.UserResponse, error) {
+	return runInTx(ctx, s.tx, func(ctx context.Context) (*domain.UserResponse, error) {
+		if err := s.teams.SetPrimary(ctx, req.TeamName, req.UserID); err != nil {
+			return nil, err
+		}
+
+		user, err := s.users.GetByID(ctx, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, domain.ErrUserNotFound
+		}
+		return &domain.UserResponse{User: *user}, nil
+	})
+}
+
+// handOverReviews replaces user in every OPEN pull request where they are a reviewer,
+// only in pull requests of teamName if it is set.
+// Pull requests without a suitable candidate keep the user and are reported separately.
+func (s *Service) handOverReviews(ctx context.Context, userID, teamName,
+	reason string) (reassigned, notReassigned []domain.ReviewHandover, err error) {
+	prs, err := s.pr.GetByReviewerID(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, pr := range prs {
+		if pr.Status != domain.PullRequestStatusOPEN {
+			continue
+		}
+		if teamName != "" {
+			full, err := s.getPullRequest(ctx, pr.PullRequestID)
+			if err != nil {
+				return nil, nil, err
+			}
+			prTeam, err := s.prTeam(ctx, full)
+			if err != nil {
+				return nil, nil, err
+			}
+			if prTeam != teamName {
+				continue
+			}
+		}
+
+		newReviewer, err := s.tryReassign(ctx, pr.PullRequestID, userID, reason)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		handover := domain.ReviewHandover{PullRequestID: pr.PullRequestID, ReplacedBy: newReviewer}
+		if newReviewer == "" {
+			notReassigned = append(notReassigned, handover)
+		} else {
+			reassigned = append(reassigned, handover)
+		}
+	}
+
+	return reassigned, notReassigned, nil
+}
+
+// tryReassign replaces reviewer on behalf of the system and returns the new one,
+// or an empty string if nobody can take the review.
+func (s *Service) tryReassign(ctx context.Context, prID, userID, reason string) (string, error) {
+	resp, err := s.reassignReviewer(ctx, prID, userID, domain.ActorSystem, reason)
+	switch {
+	case errors.Is(err, domain.ErrNoCandidatesFound), errors.Is(err, domain.ErrCodeOwnersUnavailable),
+		errors.Is(err, domain.ErrReviewersAtCapacity):
+		return "", nil
+	case err != nil:
+		return "", err
+	}
+	return resp.ReplacedBy, nil
+}
+
+// DeactivateUsers deactivates a team and/or listed users in one transaction and
+// hands their open reviews over to active users outside the deactivated set.
+// Already inactive users are processed the same way, so a repeated call finishes
+// whatever handover was not possible before.
+func (s *Service) DeactivateUsers(ctx context.Context,
+	req *domain.PostUsersDeactivateBatchJSONBody) (*domain.DeactivateBatchResponse, error) {
+	var resp *domain.DeactivateBatchResponse
+	err := s.tx.RunInTx(ctx, func(ctx context.Context) error {
+		userIDs, err := s.resolveUserSet(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		// deactivate everyone first so that candidate lookups skip the whole set
+		users := make([]domain.User, 0, len(userIDs))
+		for _, id := range userIDs {
+			user, err := s.users.GetByID(ctx, id)
+			if err != nil {
+				return err
+			}
+			if user == nil {
+				return domain.ErrUserNotFound
+			}
+
+			if user.IsActive {
+				user.IsActive = false
+				if err := s.users.Update(ctx, user); err != nil {
+					return err
+				}
+			}
+			users = append(users, *user)
+		}
+
+		handovers, err := s.handOverBatch(ctx, userIDs)
+		if err != nil {
+			return err
+		}
+
+		resp = &domain.DeactivateBatchResponse{Users: users, PullRequests: handovers}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (s *Service) resolveUserSet(ctx context.Context, req *domain.PostUsersDeactivateBatchJSONBody) ([]string, error) {
+	seen := make(map[string]bool)
+	var ids []string
+	add := func(id string) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	if req.TeamName != "" {
+		team, err := s.teams.GetByName(ctx, req.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		if team == nil {
+			return nil, domain.ErrTeamNotFound
+		}
+		for _, m := range team.Members {
+			add(m.UserID)
+		}
+	}
+	for _, id := range req.UserIDs {
+		add(id)
+	}
+
+	if len(ids) == 0 {
+		return nil, domain.ErrEmptyUserSet
+	}
+	return ids, nil
+}
+
+// handOverBatch reassigns reviews of userIDs in every affected OPEN pull request.
+func (s *Service) handOverBatch(ctx context.Context, userIDs []string) ([]domain.PullRequestHandover, error) {
+	handovers := make([]domain.PullRequestHandover, 0)
+	index := make(map[string]int)
+
+	for _, userID := range userIDs {
+		prs, err := s.pr.GetByReviewerID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pr := range prs {
+			if pr.Status != domain.PullRequestStatusOPEN {
+				continue
+			}
+
+			i, ok := index[pr.PullRequestID]
+			if !ok {
+				i = len(handovers)
+				index[pr.PullRequestID] = i
+				handovers = append(handovers, domain.PullRequestHandover{
+					PullRequestID: pr.PullRequestID,
+					Reassigned:    []domain.ReviewerReplacement{},
+					NotReassigned: []string{},
+				})
+			}
+
+			newReviewer, err := s.tryReassign(ctx, pr.PullRequestID, userID, reasonDeactivated)
+			if err != nil {
+				return nil, err
+			}
+
+			h := &handovers[i]
+			if newReviewer == "" {
+				h.NotReassigned = append(h.NotReassigned, userID)
+			} else {
+				h.Reassigned = append(h.Reassigned, domain.ReviewerReplacement{
+					OldUserID:  userID,
+					ReplacedBy: newReviewer,
+				})
+			}
+		}
+	}
+
+	return handovers, nil
 }
 
 func (s *Service) GetPrUserReviewer(ctx context.Context, userID string) (*domain.UserReviewsResponse, error) {
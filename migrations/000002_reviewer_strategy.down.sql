@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS reviewer_cursors;
+DROP TABLE IF EXISTS team_settings;
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS team_settings
+(
+    team_id           INTEGER PRIMARY KEY,
+    -- NULL means global default strategy from config
+    reviewer_strategy VARCHAR,
+    FOREIGN KEY (team_id) REFERENCES teams (id)
+);
+
+-- round-robin position per team, advanced atomically on each assignment
+CREATE TABLE IF NOT EXISTS reviewer_cursors
+(
+    team_id  INTEGER PRIMARY KEY,
+    position BIGINT NOT NULL DEFAULT 0,
+    FOREIGN KEY (team_id) REFERENCES teams (id)
+);
+
+INSERT INTO team_settings (team_id)
+SELECT id
+FROM teams
+ON CONFLICT DO NOTHING;
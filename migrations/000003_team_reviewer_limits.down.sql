@@ -0,0 +1,6 @@
+ALTER TABLE team_settings
+    DROP CONSTRAINT IF EXISTS chk_team_settings_reviewers;
+
+ALTER TABLE team_settings
+    DROP COLUMN IF EXISTS max_reviewers,
+    DROP COLUMN IF EXISTS min_reviewers;
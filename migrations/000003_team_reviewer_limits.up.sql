@@ -0,0 +1,7 @@
+ALTER TABLE team_settings
+    ADD COLUMN IF NOT EXISTS min_reviewers INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS max_reviewers INTEGER NOT NULL DEFAULT 2;
+
+ALTER TABLE team_settings
+    ADD CONSTRAINT chk_team_settings_reviewers
+        CHECK (min_reviewers >= 0 AND max_reviewers >= 1 AND min_reviewers <= max_reviewers);
@@ -0,0 +1,12 @@
+-- ordered list of teams used to fill reviewer slots when own team has too few candidates
+CREATE TABLE IF NOT EXISTS team_fallbacks
+(
+    team_id          INTEGER NOT NULL,
+    fallback_team_id INTEGER NOT NULL,
+    position         INTEGER NOT NULL,
+    FOREIGN KEY (team_id) REFERENCES teams (id),
+    FOREIGN KEY (fallback_team_id) REFERENCES teams (id),
+    PRIMARY KEY (team_id, fallback_team_id),
+    UNIQUE (team_id, position),
+    CHECK (team_id <> fallback_team_id)
+);
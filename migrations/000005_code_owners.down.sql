@@ -0,0 +1,4 @@
+ALTER TABLE pull_requests
+    DROP COLUMN IF EXISTS changed_paths;
+
+DROP TABLE IF EXISTS code_owner_rules;
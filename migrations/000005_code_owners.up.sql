@@ -0,0 +1,11 @@
+-- CODEOWNERS-style rules, for every path the rule with the highest position wins
+CREATE TABLE IF NOT EXISTS code_owner_rules
+(
+    position    INTEGER PRIMARY KEY,
+    pattern     VARCHAR   NOT NULL,
+    owner_users VARCHAR[] NOT NULL DEFAULT '{}',
+    owner_teams VARCHAR[] NOT NULL DEFAULT '{}'
+);
+
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS changed_paths VARCHAR[] NOT NULL DEFAULT '{}';
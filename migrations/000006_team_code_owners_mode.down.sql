@@ -0,0 +1,2 @@
+ALTER TABLE team_settings
+    DROP COLUMN IF EXISTS code_owners_mode;
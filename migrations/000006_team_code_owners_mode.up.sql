@@ -0,0 +1,3 @@
+ALTER TABLE team_settings
+    ADD COLUMN IF NOT EXISTS code_owners_mode VARCHAR NOT NULL DEFAULT 'PREFERRED'
+        CHECK (code_owners_mode IN ('PREFERRED', 'REQUIRED'));
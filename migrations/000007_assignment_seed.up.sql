@@ -0,0 +1,3 @@
+-- seed of the last reviewer assignment, NULL for PRs created before seeds were recorded
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS assignment_seed BIGINT;
@@ -0,0 +1,13 @@
+-- vacations, sick leaves etc., user is not assigned while starts_at <= now < ends_at
+CREATE TABLE IF NOT EXISTS user_unavailability
+(
+    id        SERIAL PRIMARY KEY,
+    user_id   INTEGER     NOT NULL,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at   TIMESTAMPTZ NOT NULL,
+    reason    VARCHAR     NOT NULL DEFAULT '',
+    FOREIGN KEY (user_id) REFERENCES users (id),
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_user_unavailability_user_id ON user_unavailability (user_id, ends_at);
@@ -0,0 +1,3 @@
+-- maximum number of OPEN pull requests a user reviews at once, NULL means unlimited
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS max_open_reviews INTEGER CHECK (max_open_reviews >= 0);
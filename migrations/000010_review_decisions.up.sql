@@ -0,0 +1,13 @@
+-- decisions of reviewers, append-only, the latest one per (pr_id, user_id) is current
+CREATE TABLE IF NOT EXISTS review_decisions
+(
+    id         SERIAL PRIMARY KEY,
+    pr_id      INTEGER     NOT NULL,
+    user_id    INTEGER     NOT NULL,
+    decision   VARCHAR     NOT NULL CHECK (decision IN ('APPROVED', 'CHANGES_REQUESTED', 'COMMENTED')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (pr_id) REFERENCES pull_requests (id),
+    FOREIGN KEY (user_id) REFERENCES users (id)
+);
+
+CREATE INDEX idx_review_decisions_pr_user ON review_decisions (pr_id, user_id, created_at DESC);
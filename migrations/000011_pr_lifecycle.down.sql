@@ -0,0 +1,23 @@
+-- statuses are referenced by pull requests: drafts become OPEN, closed pull requests
+-- become OPEN with the reviewers released on close assigned again
+INSERT INTO reviewers (pr_id, user_id)
+SELECT pr.id, u.id
+FROM pull_requests pr
+         JOIN pr_status ps ON ps.id = pr.status
+         CROSS JOIN LATERAL unnest(pr.released_reviewers) AS released (user_id)
+         JOIN users u ON u.user_id = released.user_id
+WHERE ps.name = 'CLOSED'
+ON CONFLICT DO NOTHING;
+
+UPDATE pull_requests pr
+SET status = (SELECT id FROM pr_status WHERE name = 'OPEN')
+FROM pr_status ps
+WHERE ps.id = pr.status
+  AND ps.name IN ('DRAFT', 'CLOSED');
+
+ALTER TABLE pull_requests
+    DROP COLUMN IF EXISTS released_reviewers;
+
+DELETE
+FROM pr_status
+WHERE name IN ('DRAFT', 'CLOSED');
@@ -0,0 +1,8 @@
+INSERT INTO pr_status (name)
+SELECT s.name
+FROM (VALUES ('DRAFT'), ('CLOSED')) AS s (name)
+WHERE NOT EXISTS (SELECT 1 FROM pr_status ps WHERE ps.name = s.name);
+
+-- reviewers removed when the PR was closed, restored on reopen if still eligible
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS released_reviewers VARCHAR[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS forced_merges;
+
+ALTER TABLE team_settings
+    DROP COLUMN IF EXISTS required_approvals,
+    DROP COLUMN IF EXISTS block_changes_requested;
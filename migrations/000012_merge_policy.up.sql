@@ -0,0 +1,16 @@
+ALTER TABLE team_settings
+    ADD COLUMN IF NOT EXISTS required_approvals      INTEGER NOT NULL DEFAULT 0 CHECK (required_approvals >= 0),
+    ADD COLUMN IF NOT EXISTS block_changes_requested BOOL    NOT NULL DEFAULT false;
+
+-- audit of merges that bypassed the merge policy
+CREATE TABLE IF NOT EXISTS forced_merges
+(
+    id        SERIAL PRIMARY KEY,
+    pr_id     INTEGER     NOT NULL,
+    actor     VARCHAR     NOT NULL,
+    missing   VARCHAR[]   NOT NULL DEFAULT '{}',
+    forced_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (pr_id) REFERENCES pull_requests (id)
+);
+
+CREATE INDEX idx_forced_merges_pr_id ON forced_merges (pr_id);
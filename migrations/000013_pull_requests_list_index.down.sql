@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_pull_requests_status_created_at;
+
+ALTER TABLE pull_requests
+    ALTER COLUMN merged_at TYPE TIMESTAMP USING merged_at AT TIME ZONE 'UTC',
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'UTC';
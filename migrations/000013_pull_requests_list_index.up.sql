@@ -0,0 +1,7 @@
+-- created_at and merged_at held the application's wall time, the application runs in UTC;
+-- list filters compare them with client timestamps carrying an offset
+ALTER TABLE pull_requests
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC',
+    ALTER COLUMN merged_at TYPE TIMESTAMPTZ USING merged_at AT TIME ZONE 'UTC';
+
+CREATE INDEX IF NOT EXISTS idx_pull_requests_status_created_at ON pull_requests (status, created_at);
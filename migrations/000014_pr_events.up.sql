@@ -0,0 +1,19 @@
+-- append-only history of pull request changes, rows are never updated or deleted
+CREATE TABLE IF NOT EXISTS pr_events
+(
+    id          BIGSERIAL PRIMARY KEY,
+    pr_id       INTEGER     NOT NULL,
+    event_type  VARCHAR     NOT NULL,
+    actor       VARCHAR     NOT NULL DEFAULT '',
+    reviewer_id VARCHAR     NOT NULL DEFAULT '',
+    replaced_by VARCHAR     NOT NULL DEFAULT '',
+    from_status VARCHAR     NOT NULL DEFAULT '',
+    to_status   VARCHAR     NOT NULL DEFAULT '',
+    details     VARCHAR     NOT NULL DEFAULT '',
+    -- seed of the assignment that produced the event, NULL for events without random choice
+    seed        BIGINT,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (pr_id) REFERENCES pull_requests (id)
+);
+
+CREATE INDEX idx_pr_events_pr_id ON pr_events (pr_id, id);
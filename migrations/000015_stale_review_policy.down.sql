@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_pr_events_reviewer;
+
+ALTER TABLE team_settings
+    DROP COLUMN IF EXISTS stale_action;
@@ -0,0 +1,6 @@
+-- what to do with reviews not acted on within the SLA
+ALTER TABLE team_settings
+    ADD COLUMN IF NOT EXISTS stale_action VARCHAR NOT NULL DEFAULT 'NONE';
+
+-- speeds up the lookup of the latest activity of a reviewer
+CREATE INDEX IF NOT EXISTS idx_pr_events_reviewer ON pr_events (pr_id, reviewer_id);
@@ -0,0 +1,41 @@
+-- every reviewer a pull request has ever had, unassigned_at is set while the user is not assigned,
+-- removed_at while the user stays excluded after an explicit removal or reassignment
+CREATE TABLE IF NOT EXISTS pr_reviewer_history
+(
+    pr_id         INTEGER     NOT NULL,
+    user_id       INTEGER     NOT NULL,
+    assigned_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    unassigned_at TIMESTAMPTZ,
+    removed_at    TIMESTAMPTZ,
+    FOREIGN KEY (pr_id) REFERENCES pull_requests (id),
+    FOREIGN KEY (user_id) REFERENCES users (id),
+    PRIMARY KEY (pr_id, user_id)
+);
+
+-- backfill from current reviewers and recorded history, the current assignment started
+-- at the latest assignment event, PRs without such events were assigned on creation
+INSERT INTO pr_reviewer_history (pr_id, user_id, assigned_at)
+SELECT r.pr_id, r.user_id, COALESCE(
+        (SELECT MAX(e.created_at)
+         FROM pr_events e
+                  JOIN users u ON u.id = r.user_id
+         WHERE e.pr_id = r.pr_id
+           AND ((e.event_type = 'REVIEWER_ASSIGNED' AND e.reviewer_id = u.user_id)
+             OR (e.event_type = 'REVIEWER_REASSIGNED' AND e.replaced_by = u.user_id))),
+        pr.created_at)
+FROM reviewers r
+         JOIN pull_requests pr ON pr.id = r.pr_id
+ON CONFLICT DO NOTHING;
+
+-- reviewers released on close are unassigned but not removed
+INSERT INTO pr_reviewer_history (pr_id, user_id, assigned_at, unassigned_at, removed_at)
+SELECT e.pr_id,
+       u.id,
+       MIN(e.created_at),
+       MAX(e.created_at),
+       MAX(e.created_at) FILTER (WHERE e.event_type = 'REVIEWER_REASSIGNED' OR e.details = 'removed manually')
+FROM pr_events e
+         JOIN users u ON u.user_id = e.reviewer_id
+WHERE e.event_type IN ('REVIEWER_REASSIGNED', 'REVIEWER_REMOVED')
+GROUP BY e.pr_id, u.id
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,7 @@
+ALTER TABLE team_settings
+    DROP COLUMN IF EXISTS extra_reviewers_critical,
+    DROP COLUMN IF EXISTS extra_reviewers_high;
+
+ALTER TABLE pull_requests
+    DROP COLUMN IF EXISTS labels,
+    DROP COLUMN IF EXISTS priority;
@@ -0,0 +1,8 @@
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS priority VARCHAR   NOT NULL DEFAULT 'NORMAL',
+    ADD COLUMN IF NOT EXISTS labels   VARCHAR[] NOT NULL DEFAULT '{}';
+
+-- reviewers added on top of max_reviewers for urgent pull requests of the team's authors
+ALTER TABLE team_settings
+    ADD COLUMN IF NOT EXISTS extra_reviewers_high     INTEGER NOT NULL DEFAULT 0 CHECK (extra_reviewers_high >= 0),
+    ADD COLUMN IF NOT EXISTS extra_reviewers_critical INTEGER NOT NULL DEFAULT 0 CHECK (extra_reviewers_critical >= 0);
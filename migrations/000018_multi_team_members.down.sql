@@ -0,0 +1,8 @@
+ALTER TABLE pull_requests
+    DROP COLUMN IF EXISTS team_id;
+
+DROP INDEX IF EXISTS idx_team_member_user_id;
+DROP INDEX IF EXISTS uq_team_member_primary;
+
+ALTER TABLE team_member
+    DROP COLUMN IF EXISTS is_primary;
@@ -0,0 +1,20 @@
+-- a user may belong to several teams, the primary one is used for assignment
+ALTER TABLE team_member
+    ADD COLUMN IF NOT EXISTS is_primary BOOL NOT NULL DEFAULT FALSE;
+
+-- so far every user had exactly one membership
+UPDATE team_member
+SET is_primary = TRUE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_team_member_primary ON team_member (user_id) WHERE is_primary;
+CREATE INDEX IF NOT EXISTS idx_team_member_user_id ON team_member (user_id);
+
+-- team whose members review the pull request, one of the author's teams
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS team_id INTEGER REFERENCES teams (id) ON DELETE SET NULL;
+
+UPDATE pull_requests pr
+SET team_id = tm.team_id
+FROM team_member tm
+WHERE tm.user_id = pr.author_id
+  AND tm.is_primary;
@@ -0,0 +1,2 @@
+-- case-insensitive username prefix search of /users/list
+CREATE INDEX IF NOT EXISTS idx_users_username_prefix ON users (lower(username) text_pattern_ops);
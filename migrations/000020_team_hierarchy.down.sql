@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS trg_teams_prevent_cycle ON teams;
+DROP FUNCTION IF EXISTS teams_prevent_cycle();
+
+DROP INDEX IF EXISTS idx_teams_parent_id;
+
+ALTER TABLE teams
+    DROP CONSTRAINT IF EXISTS chk_teams_parent,
+    DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,39 @@
+-- squads belong to tribes, tribes to departments
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS parent_id INTEGER REFERENCES teams (id) ON DELETE SET NULL,
+    ADD CONSTRAINT chk_teams_parent CHECK (parent_id <> id);
+
+CREATE INDEX IF NOT EXISTS idx_teams_parent_id ON teams (parent_id);
+
+-- a team must not become its own ancestor
+CREATE OR REPLACE FUNCTION teams_prevent_cycle() RETURNS TRIGGER AS
+$$
+BEGIN
+    IF NEW.parent_id IS NULL THEN
+        RETURN NEW;
+    END IF;
+
+    -- hierarchy changes are serialized, so two concurrent moves cannot close a cycle together
+    PERFORM pg_advisory_xact_lock(hashtext('teams_hierarchy'));
+
+    IF EXISTS (WITH RECURSIVE ancestors (id) AS (SELECT NEW.parent_id
+                                                 UNION
+                                                 SELECT t.parent_id
+                                                 FROM teams t
+                                                          JOIN ancestors a ON t.id = a.id
+                                                 WHERE t.parent_id IS NOT NULL)
+               SELECT 1
+               FROM ancestors
+               WHERE id = NEW.id) THEN
+        RAISE EXCEPTION 'team % would be its own ancestor', NEW.name USING ERRCODE = 'check_violation';
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_teams_prevent_cycle
+    BEFORE INSERT OR UPDATE OF parent_id
+    ON teams
+    FOR EACH ROW
+EXECUTE FUNCTION teams_prevent_cycle();
@@ -0,0 +1,5 @@
+ALTER TABLE team_settings
+    DROP COLUMN IF EXISTS require_maintainer;
+
+ALTER TABLE team_member
+    DROP COLUMN IF EXISTS role;
@@ -0,0 +1,8 @@
+-- role of the user in the team, maintainers and the lead can be required among reviewers
+ALTER TABLE team_member
+    ADD COLUMN IF NOT EXISTS role VARCHAR NOT NULL DEFAULT 'MEMBER'
+        CHECK (role IN ('MEMBER', 'MAINTAINER', 'LEAD'));
+
+-- at least one maintainer or lead among reviewers of the team's pull requests
+ALTER TABLE team_settings
+    ADD COLUMN IF NOT EXISTS require_maintainer BOOL NOT NULL DEFAULT FALSE;
@@ -0,0 +1,3 @@
+-- archived teams are no longer in use and may be deleted together with their memberships
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS archived BOOLEAN NOT NULL DEFAULT FALSE;
@@ -9,6 +9,7 @@ import (
 const (
 	ForeignKeyViolationCode = "23503"
 	UniqueViolationCode     = "23505"
+	CheckViolationCode      = "23514"
 )
 
 func IsUniqueViolation(err error) bool {
@@ -20,3 +21,8 @@ func IsForeignKeyViolation(err error) bool {
 	var pgErr *pgconn.PgError
 	return errors.As(err, &pgErr) && pgErr.Code == ForeignKeyViolationCode
 }
+
+func IsCheckViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == CheckViolationCode
+}
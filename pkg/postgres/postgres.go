@@ -100,7 +100,12 @@ func (p *Postgres) Close() {
 	}
 }
 
+// RunInTx runs fn in a transaction; if ctx already carries one, fn joins it.
 func (p *Postgres) RunInTx(ctx context.Context, fn func(context.Context) error) error {
+	if _, ok := ctx.Value(txKey).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
 	tx, err := p.Pool.Begin(ctx)
 	if err != nil {
 		return err
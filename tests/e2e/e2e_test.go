@@ -8,6 +8,8 @@ import (
 	"io"
 	"math/rand"
 	"net/http"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -23,26 +25,93 @@ type TeamMember struct {
 	UserID   string `json:"user_id"`
 	Username string `json:"username"`
 	IsActive bool   `json:"is_active"`
+	Role     string `json:"role,omitempty"`
 }
 
-type TeamRequest struct {
+type TeamSettings struct {
+	ReviewerStrategy  string         `json:"reviewer_strategy,omitempty"`
+	MinReviewers      int            `json:"min_reviewers,omitempty"`
+	MaxReviewers      int            `json:"max_reviewers,omitempty"`
+	FallbackTeams     []string       `json:"fallback_teams,omitempty"`
+	MergePolicy       *MergePolicy   `json:"merge_policy,omitempty"`
+	StaleReview       *StaleReview   `json:"stale_review,omitempty"`
+	PriorityRules     *PriorityRules `json:"priority_rules,omitempty"`
+	RequireMaintainer bool           `json:"require_maintainer,omitempty"`
+}
+
+type PriorityRules struct {
+	ExtraReviewersHigh     int `json:"extra_reviewers_high"`
+	ExtraReviewersCritical int `json:"extra_reviewers_critical"`
+}
+
+type MergePolicy struct {
+	RequiredApprovals     int  `json:"required_approvals"`
+	BlockChangesRequested bool `json:"block_changes_requested"`
+}
+
+type StaleReview struct {
+	Action string `json:"action"`
+}
+
+type StaleScanReq struct {
+	Before        time.Time `json:"before"`
+	PullRequestID string    `json:"pull_request_id,omitempty"`
+	DryRun        bool      `json:"dry_run,omitempty"`
+}
+
+type StaleScanResponse struct {
+	StaleReviews []struct {
+		PullRequestID string `json:"pull_request_id"`
+		ReviewerID    string `json:"reviewer_id"`
+		Action        string `json:"action"`
+		Target        string `json:"target"`
+	} `json:"stale_reviews"`
+}
+
+type PRHistoryResponse struct {
+	Events []struct {
+		Type       string `json:"type"`
+		Actor      string `json:"actor"`
+		ReviewerID string `json:"reviewer_id"`
+		ReplacedBy string `json:"replaced_by"`
+		Details    string `json:"details"`
+		Seed       *int64 `json:"seed"`
+	} `json:"events"`
+}
+
+type TeamSettingsRequest struct {
 	TeamName string       `json:"team_name"`
-	Members  []TeamMember `json:"members"`
+	Settings TeamSettings `json:"settings"`
+}
+
+type TeamRequest struct {
+	TeamName   string        `json:"team_name"`
+	ParentTeam string        `json:"parent_team,omitempty"`
+	Members    []TeamMember  `json:"members"`
+	Settings   *TeamSettings `json:"settings,omitempty"`
 }
 
 type PullRequestCreateReq struct {
-	PullRequestID   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
-	AuthorID        string `json:"author_id"`
+	PullRequestID   string   `json:"pull_request_id"`
+	PullRequestName string   `json:"pull_request_name"`
+	AuthorID        string   `json:"author_id"`
+	ChangedPaths    []string `json:"changed_paths,omitempty"`
+	Draft           bool     `json:"draft,omitempty"`
+	Priority        string   `json:"priority,omitempty"`
+	Labels          []string `json:"labels,omitempty"`
+	TeamName        string   `json:"team_name,omitempty"`
 }
 
 type PullRequestMergeReq struct {
 	PullRequestID string `json:"pull_request_id"`
+	Force         bool   `json:"force,omitempty"`
+	Actor         string `json:"actor,omitempty"`
 }
 
 type PullRequestReassignReq struct {
 	PullRequestID string `json:"pull_request_id"`
 	OldUserID     string `json:"old_user_id"`
+	Actor         string `json:"actor,omitempty"`
 }
 
 type PullRequestResponse struct {
@@ -51,27 +120,48 @@ type PullRequestResponse struct {
 		Name              string   `json:"pull_request_name"`
 		AuthorID          string   `json:"author_id"`
 		Status            string   `json:"status"`
+		TeamName          string   `json:"team_name"`
+		Priority          string   `json:"priority"`
+		Labels            []string `json:"labels"`
 		AssignedReviewers []string `json:"assigned_reviewers"`
-		CreatedAt         *string  `json:"createdAt"`
-		MergedAt          *string  `json:"mergedAt"`
+		AssignmentSeed    *int64   `json:"assignment_seed"`
+		Reviewers         []struct {
+			UserID   string `json:"user_id"`
+			Username string `json:"username"`
+			TeamName string `json:"team_name"`
+		} `json:"reviewers"`
+		ReviewDecisions []struct {
+			UserID   string `json:"user_id"`
+			Decision string `json:"decision"`
+		} `json:"review_decisions"`
+		CreatedAt *string `json:"createdAt"`
+		MergedAt  *string `json:"mergedAt"`
 	} `json:"pr"`
-	ReplacedBy string `json:"replaced_by,omitempty"`
+	ReplacedBy        string `json:"replaced_by,omitempty"`
+	MaintainerMissing bool   `json:"maintainer_missing"`
+	FallbackReviewers []struct {
+		UserID   string `json:"user_id"`
+		TeamName string `json:"team_name"`
+	} `json:"fallback_reviewers"`
 }
 
 type UserReviewsResponse struct {
 	UserID       string `json:"user_id"`
 	PullRequests []struct {
-		ID       string `json:"pull_request_id"`
-		Name     string `json:"pull_request_name"`
-		AuthorID string `json:"author_id"`
-		Status   string `json:"status"`
+		ID       string   `json:"pull_request_id"`
+		Name     string   `json:"pull_request_name"`
+		AuthorID string   `json:"author_id"`
+		Status   string   `json:"status"`
+		Priority string   `json:"priority"`
+		Labels   []string `json:"labels"`
 	} `json:"pull_requests"`
 }
 
 type ErrorResponse struct {
 	Error struct {
-		Code    string `json:"code"`
-		Message string `json:"message"`
+		Code    string   `json:"code"`
+		Message string   `json:"message"`
+		Details []string `json:"details"`
 	} `json:"error"`
 }
 
@@ -151,11 +241,92 @@ func TestE2E_AutoAssign(t *testing.T) {
 	assert.Contains(t, resp.PR.AssignedReviewers, rev1)
 	assert.Contains(t, resp.PR.AssignedReviewers, rev2)
 
-	// Проверяем наличие createdAt
+	// Проверяем наличие createdAt и seed назначения
 	assert.NotNil(t, resp.PR.CreatedAt, "CreatedAt should be set")
+	assert.NotNil(t, resp.PR.AssignmentSeed, "AssignmentSeed should be recorded")
 	assert.Nil(t, resp.PR.MergedAt, "MergedAt should be nil for OPEN PR")
 }
 
+func TestE2E_DeterministicAssignmentSeed(t *testing.T) {
+	// Тест на seed назначений (ASSIGNMENT_DETERMINISTIC=true в .env.test): seed пишется в PR и в каждое событие
+	// назначения, переназначение не затирает seed создания в истории, а id PR входит в seed.
+	// Воспроизведение выбора по seed проверяется unit-тестами usecase
+	teamName := randomString("team_seed")
+	author := randomString("u_auth")
+	members := []TeamMember{{UserID: author, Username: "Author", IsActive: true}}
+	for i := 0; i < 4; i++ {
+		members = append(members, TeamMember{UserID: randomString("u_rev"), Username: "R", IsActive: true})
+	}
+
+	code, _ := sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members:  members,
+		Settings: &TeamSettings{ReviewerStrategy: "RANDOM", MaxReviewers: 2},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	prID := randomString("pr_seed")
+	code, body := sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: prID, PullRequestName: "Seed", AuthorID: author,
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	var resp PullRequestResponse
+	json.Unmarshal(body, &resp)
+	require.NotNil(t, resp.PR.AssignmentSeed)
+	createSeed := *resp.PR.AssignmentSeed
+
+	code, body = sendRequest(t, "GET", "/pullRequest/get?pull_request_id="+prID, nil)
+	require.Equal(t, http.StatusOK, code)
+	var stored PullRequestResponse
+	json.Unmarshal(body, &stored)
+	require.NotNil(t, stored.PR.AssignmentSeed)
+	assert.Equal(t, createSeed, *stored.PR.AssignmentSeed)
+	assert.Equal(t, resp.PR.AssignedReviewers, stored.PR.AssignedReviewers)
+
+	old := resp.PR.AssignedReviewers[0]
+	code, body = sendRequest(t, "POST", "/pullRequest/reassign", PullRequestReassignReq{PullRequestID: prID, OldUserID: old})
+	require.Equal(t, http.StatusOK, code)
+	resp = PullRequestResponse{}
+	json.Unmarshal(body, &resp)
+	require.NotNil(t, resp.PR.AssignmentSeed)
+	reassignSeed := *resp.PR.AssignmentSeed
+	// кандидатов стало меньше, поэтому и seed другой
+	assert.NotEqual(t, createSeed, reassignSeed)
+
+	code, body = sendRequest(t, "GET", "/pullRequest/history?pull_request_id="+prID, nil)
+	require.Equal(t, http.StatusOK, code)
+	var history PRHistoryResponse
+	json.Unmarshal(body, &history)
+
+	var assignedEvents, reassignedEvents int
+	for _, e := range history.Events {
+		switch e.Type {
+		case "REVIEWER_ASSIGNED":
+			assignedEvents++
+			require.NotNil(t, e.Seed)
+			assert.Equal(t, createSeed, *e.Seed)
+		case "REVIEWER_REASSIGNED":
+			reassignedEvents++
+			require.NotNil(t, e.Seed)
+			assert.Equal(t, reassignSeed, *e.Seed)
+		case "CREATED":
+			assert.Nil(t, e.Seed)
+		}
+	}
+	assert.Equal(t, 2, assignedEvents)
+	assert.Equal(t, 1, reassignedEvents)
+
+	code, body = sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: randomString("pr_seed"), PullRequestName: "Seed 2", AuthorID: author,
+	})
+	require.Equal(t, http.StatusCreated, code)
+	resp = PullRequestResponse{}
+	json.Unmarshal(body, &resp)
+	require.NotNil(t, resp.PR.AssignmentSeed)
+	assert.NotEqual(t, createSeed, *resp.PR.AssignmentSeed)
+}
+
 func TestE2E_NotEnoughCandidates(t *testing.T) {
 	// Сценарий: В команде только Автор и 1 коллега. Должен назначиться только 1 ревьювер.
 	teamName := randomString("team_small")
@@ -681,3 +852,2317 @@ func TestE2E_GetTeamSuccess(t *testing.T) {
 	assert.Contains(t, memberIDs, user1)
 	assert.Contains(t, memberIDs, user2)
 }
+
+func TestE2E_RoundRobinStrategy(t *testing.T) {
+	// Сценарий: у команды стратегия ROUND_ROBIN. Ревьюверы назначаются по кругу в порядке user_id.
+	teamName := randomString("team_rr")
+	author := randomString("u_auth")
+	revs := []string{randomString("u_rev1"), randomString("u_rev2"), randomString("u_rev3")}
+
+	code, _ := sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "A", IsActive: true},
+			{UserID: revs[0], Username: "R1", IsActive: true},
+			{UserID: revs[1], Username: "R2", IsActive: true},
+			{UserID: revs[2], Username: "R3", IsActive: true},
+		},
+		Settings: &TeamSettings{ReviewerStrategy: "ROUND_ROBIN"},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	sort.Strings(revs)
+	expected := [][]string{{revs[0], revs[1]}, {revs[2], revs[0]}}
+
+	for _, want := range expected {
+		code, body := sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+			PullRequestID: randomString("pr_rr"), PullRequestName: "RR", AuthorID: author,
+		})
+		require.Equal(t, http.StatusCreated, code)
+
+		var resp PullRequestResponse
+		json.Unmarshal(body, &resp)
+		assert.Equal(t, want, resp.PR.AssignedReviewers)
+	}
+}
+
+func TestE2E_InvalidReviewerStrategy(t *testing.T) {
+	// Тест на создание команды с неизвестной стратегией
+	code, body := sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: randomString("team_bad_strategy"),
+		Members:  []TeamMember{{UserID: randomString("u_auth"), Username: "A", IsActive: true}},
+		Settings: &TeamSettings{ReviewerStrategy: "BY_MOOD"},
+	})
+
+	assert.Equal(t, http.StatusBadRequest, code)
+	var errResp ErrorResponse
+	json.Unmarshal(body, &errResp)
+	assert.Equal(t, "BAD_REQUEST", errResp.Error.Code)
+}
+
+func TestE2E_LeastLoadedStrategy(t *testing.T) {
+	// Сценарий: у команды стратегия LEAST_LOADED. Второй PR обязан достаться тому, кто не получил первый.
+	teamName := randomString("team_ll")
+	author := randomString("u_auth")
+	revs := []string{randomString("u_rev1"), randomString("u_rev2"), randomString("u_rev3")}
+
+	code, _ := sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "A", IsActive: true},
+			{UserID: revs[0], Username: "R1", IsActive: true},
+			{UserID: revs[1], Username: "R2", IsActive: true},
+			{UserID: revs[2], Username: "R3", IsActive: true},
+		},
+		Settings: &TeamSettings{ReviewerStrategy: "LEAST_LOADED"},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	code, body := sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: randomString("pr_ll"), PullRequestName: "LL1", AuthorID: author,
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	var first PullRequestResponse
+	json.Unmarshal(body, &first)
+	require.Len(t, first.PR.AssignedReviewers, 2)
+
+	idle := ""
+	for _, r := range revs {
+		if !contains(first.PR.AssignedReviewers, r) {
+			idle = r
+		}
+	}
+
+	code, body = sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: randomString("pr_ll"), PullRequestName: "LL2", AuthorID: author,
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	var second PullRequestResponse
+	json.Unmarshal(body, &second)
+	assert.Contains(t, second.PR.AssignedReviewers, idle, "Least loaded reviewer must be picked")
+}
+
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestE2E_TeamReviewerLimits(t *testing.T) {
+	// Сценарий: команда из 4 человек, max_reviewers=3 назначает троих; min_reviewers=4 не даёт создать PR.
+	teamName := randomString("team_limits")
+	author := randomString("u_auth")
+
+	code, _ := sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "A", IsActive: true},
+			{UserID: randomString("u_rev1"), Username: "R1", IsActive: true},
+			{UserID: randomString("u_rev2"), Username: "R2", IsActive: true},
+			{UserID: randomString("u_rev3"), Username: "R3", IsActive: true},
+		},
+		Settings: &TeamSettings{MaxReviewers: 3},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	code, body := sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: randomString("pr_limits"), PullRequestName: "Three", AuthorID: author,
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	var resp PullRequestResponse
+	json.Unmarshal(body, &resp)
+	assert.Len(t, resp.PR.AssignedReviewers, 3)
+
+	code, _ = sendRequest(t, "POST", "/team/setSettings", TeamSettingsRequest{
+		TeamName: teamName,
+		Settings: TeamSettings{MinReviewers: 4, MaxReviewers: 4},
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	code, body = sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: randomString("pr_limits"), PullRequestName: "Four", AuthorID: author,
+	})
+	assert.Equal(t, http.StatusConflict, code)
+
+	var errResp ErrorResponse
+	json.Unmarshal(body, &errResp)
+	assert.Equal(t, "NOT_ENOUGH_REVIEWERS", errResp.Error.Code)
+}
+
+func TestE2E_TeamSettingsPatch(t *testing.T) {
+	// Тест: /team/setSettings меняет только переданные настройки, fallback_teams заменяется только если передан
+	teamName := randomString("team_patch")
+	fallback := randomString("team_patch_fb")
+
+	code, _ := sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: fallback,
+		Members:  []TeamMember{{UserID: randomString("u_fb"), Username: "F", IsActive: true}},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	code, _ = sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members:  []TeamMember{{UserID: randomString("u_member"), Username: "M", IsActive: true}},
+		Settings: &TeamSettings{
+			ReviewerStrategy: "ROUND_ROBIN",
+			MaxReviewers:     3,
+			FallbackTeams:    []string{fallback},
+			MergePolicy:      &MergePolicy{RequiredApprovals: 2, BlockChangesRequested: true},
+			StaleReview:      &StaleReview{Action: "REASSIGN"},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	getSettings := func() TeamSettings {
+		code, body := sendRequest(t, "GET", "/team/get?team_name="+teamName, nil)
+		require.Equal(t, http.StatusOK, code)
+
+		var team struct {
+			Settings TeamSettings `json:"settings"`
+		}
+		json.Unmarshal(body, &team)
+		return team.Settings
+	}
+
+	code, _ = sendRequest(t, "POST", "/team/setSettings", map[string]interface{}{
+		"team_name": teamName,
+		"settings": map[string]interface{}{
+			"min_reviewers": 1,
+			"merge_policy":  map[string]interface{}{"required_approvals": 1},
+		},
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	settings := getSettings()
+	assert.Equal(t, "ROUND_ROBIN", settings.ReviewerStrategy)
+	assert.Equal(t, 1, settings.MinReviewers)
+	assert.Equal(t, 3, settings.MaxReviewers)
+	assert.Equal(t, []string{fallback}, settings.FallbackTeams)
+	require.NotNil(t, settings.MergePolicy)
+	assert.Equal(t, 1, settings.MergePolicy.RequiredApprovals)
+	assert.True(t, settings.MergePolicy.BlockChangesRequested)
+	require.NotNil(t, settings.StaleReview)
+	assert.Equal(t, "REASSIGN", settings.StaleReview.Action)
+
+	code, _ = sendRequest(t, "POST", "/team/setSettings", map[string]interface{}{
+		"team_name": teamName,
+		"settings":  map[string]interface{}{"fallback_teams": []string{}},
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	settings = getSettings()
+	assert.Empty(t, settings.FallbackTeams)
+	assert.Equal(t, 3, settings.MaxReviewers)
+
+	// Итоговые настройки проверяются целиком: min_reviewers больше текущего max_reviewers отклоняется
+	code, _ = sendRequest(t, "POST", "/team/setSettings", map[string]interface{}{
+		"team_name": teamName,
+		"settings":  map[string]interface{}{"min_reviewers": 4},
+	})
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// явный 0 в max_reviewers не заменяется значением по умолчанию, а отклоняется
+	var errResp ErrorResponse
+	code, body := sendRequest(t, "POST", "/team/setSettings", map[string]interface{}{
+		"team_name": teamName,
+		"settings":  map[string]interface{}{"max_reviewers": 0},
+	})
+	assert.Equal(t, http.StatusBadRequest, code)
+	json.Unmarshal(body, &errResp)
+	assert.Equal(t, "INVALID_TEAM_SETTINGS", errResp.Error.Code)
+	assert.Equal(t, 3, getSettings().MaxReviewers)
+
+	code, body = sendRequest(t, "POST", "/team/add", map[string]interface{}{
+		"team_name": randomString("team_zero"),
+		"members":   []TeamMember{{UserID: randomString("u_zero"), Username: "Z", IsActive: true}},
+		"settings":  map[string]interface{}{"max_reviewers": 0},
+	})
+	assert.Equal(t, http.StatusBadRequest, code)
+	errResp = ErrorResponse{}
+	json.Unmarshal(body, &errResp)
+	assert.Equal(t, "INVALID_TEAM_SETTINGS", errResp.Error.Code)
+}
+
+func TestE2E_DraftReviewersOverLimit(t *testing.T) {
+	// Сценарий: к черновику вручную добавлены три ревьювера, затем лимит команды снижен до двух —
+	// ready не отбрасывает лишнего молча, а возвращает REVIEWER_LIMIT
+	teamName := randomString("team_draft_limit")
+	author := randomString("u_auth")
+	reviewers := []string{randomString("u_rev1"), randomString("u_rev2"), randomString("u_rev3")}
+
+	members := []TeamMember{{UserID: author, Username: "A", IsActive: true}}
+	for _, id := range reviewers {
+		members = append(members, TeamMember{UserID: id, Username: "R", IsActive: true})
+	}
+	code, _ := sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members:  members,
+		Settings: &TeamSettings{MaxReviewers: 3},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	prID := randomString("pr_draft_limit")
+	code, _ = sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: prID, PullRequestName: "Draft limit", AuthorID: author, Draft: true,
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	for _, id := range reviewers {
+		code, _ = sendRequest(t, "POST", "/pullRequest/addReviewer", map[string]string{
+			"pull_request_id": prID, "user_id": id, "actor": "e2e-admin",
+		})
+		require.Equal(t, http.StatusOK, code)
+	}
+
+	code, _ = sendRequest(t, "POST", "/team/setSettings", map[string]interface{}{
+		"team_name": teamName,
+		"settings":  map[string]interface{}{"max_reviewers": 2},
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	var errResp ErrorResponse
+	code, body := sendRequest(t, "POST", "/pullRequest/ready", PullRequestMergeReq{PullRequestID: prID})
+	assert.Equal(t, http.StatusConflict, code)
+	json.Unmarshal(body, &errResp)
+	assert.Equal(t, "REVIEWER_LIMIT", errResp.Error.Code)
+
+	code, _ = sendRequest(t, "POST", "/pullRequest/removeReviewer", map[string]string{
+		"pull_request_id": prID, "user_id": reviewers[2], "actor": "e2e-admin",
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	code, body = sendRequest(t, "POST", "/pullRequest/ready", PullRequestMergeReq{PullRequestID: prID})
+	require.Equal(t, http.StatusOK, code)
+	var resp PullRequestResponse
+	json.Unmarshal(body, &resp)
+	assert.Equal(t, "OPEN", resp.PR.Status)
+	assert.Equal(t, reviewers[:2], resp.PR.AssignedReviewers)
+}
+
+func TestE2E_FallbackTeam(t *testing.T) {
+	// Сценарий: в команде автора один коллега, второй ревьювер добирается из резервной команды.
+	fallbackTeam := randomString("team_fallback")
+	helper := randomString("u_helper")
+	sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: fallbackTeam,
+		Members:  []TeamMember{{UserID: helper, Username: "H", IsActive: true}},
+	})
+
+	teamName := randomString("team_small")
+	author := randomString("u_auth")
+	rev1 := randomString("u_rev1")
+	code, _ := sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "A", IsActive: true},
+			{UserID: rev1, Username: "R1", IsActive: true},
+		},
+		Settings: &TeamSettings{FallbackTeams: []string{fallbackTeam}},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	code, body := sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: randomString("pr_fallback"), PullRequestName: "Fallback", AuthorID: author,
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	var resp PullRequestResponse
+	json.Unmarshal(body, &resp)
+	assert.ElementsMatch(t, []string{rev1, helper}, resp.PR.AssignedReviewers)
+	require.Len(t, resp.FallbackReviewers, 1)
+	assert.Equal(t, helper, resp.FallbackReviewers[0].UserID)
+	assert.Equal(t, fallbackTeam, resp.FallbackReviewers[0].TeamName)
+}
+
+func TestE2E_CodeOwnersPreferred(t *testing.T) {
+	// Сценарий: изменённый файл принадлежит команде владельцев, один ревьювер должен быть оттуда.
+	ownersTeam := randomString("team_owners")
+	owner := randomString("u_owner")
+	sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: ownersTeam,
+		Members:  []TeamMember{{UserID: owner, Username: "O", IsActive: true}},
+	})
+
+	teamName := randomString("team_co")
+	author := randomString("u_auth")
+	sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "A", IsActive: true},
+			{UserID: randomString("u_rev1"), Username: "R1", IsActive: true},
+			{UserID: randomString("u_rev2"), Username: "R2", IsActive: true},
+		},
+	})
+
+	dir := randomString("dir")
+	code, _ := sendRequest(t, "POST", "/codeOwners/import", map[string]string{
+		"content": fmt.Sprintf("/%s/ @org/%s\n", dir, ownersTeam),
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	code, body := sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID:   randomString("pr_co"),
+		PullRequestName: "Owned",
+		AuthorID:        author,
+		ChangedPaths:    []string{dir + "/main.go"},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	var resp PullRequestResponse
+	json.Unmarshal(body, &resp)
+	assert.Len(t, resp.PR.AssignedReviewers, 2)
+	assert.Contains(t, resp.PR.AssignedReviewers, owner, "Code owner must be assigned")
+
+	sendRequest(t, "POST", "/codeOwners/set", map[string]interface{}{"rules": []interface{}{}})
+}
+
+func TestE2E_UnavailableUserIgnored(t *testing.T) {
+	// Сценарий: один из коллег в отпуске прямо сейчас, второй — в будущем. Назначается только доступный.
+	teamName := randomString("team_vacation")
+	author := randomString("u_auth")
+	onVacation := randomString("u_vacation")
+	future := randomString("u_future")
+
+	sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "A", IsActive: true},
+			{UserID: onVacation, Username: "V", IsActive: true},
+			{UserID: future, Username: "F", IsActive: true},
+		},
+	})
+
+	now := time.Now().UTC()
+	code, _ := sendRequest(t, "POST", "/users/unavailability/add", map[string]interface{}{
+		"user_id":   onVacation,
+		"starts_at": now.Add(-time.Hour),
+		"ends_at":   now.Add(24 * time.Hour),
+		"reason":    "vacation",
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	code, _ = sendRequest(t, "POST", "/users/unavailability/add", map[string]interface{}{
+		"user_id":   future,
+		"starts_at": now.Add(24 * time.Hour),
+		"ends_at":   now.Add(48 * time.Hour),
+		"reason":    "sick leave",
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	code, body := sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: randomString("pr_vacation"), PullRequestName: "Vacation", AuthorID: author,
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	var resp PullRequestResponse
+	json.Unmarshal(body, &resp)
+	assert.Equal(t, []string{future}, resp.PR.AssignedReviewers)
+
+	code, body = sendRequest(t, "GET", fmt.Sprintf("/users/unavailability/list?user_id=%s", onVacation), nil)
+	require.Equal(t, http.StatusOK, code)
+
+	var list struct {
+		Unavailability []struct {
+			Reason string `json:"reason"`
+		} `json:"unavailability"`
+	}
+	json.Unmarshal(body, &list)
+	require.Len(t, list.Unavailability, 1)
+	assert.Equal(t, "vacation", list.Unavailability[0].Reason)
+}
+
+func TestE2E_DeactivateReassignsReviews(t *testing.T) {
+	// Сценарий: ревьювер уходит из компании, его открытое ревью переходит к коллеге.
+	// В команде без свободных коллег PR попадает в not_reassigned.
+	teamName := randomString("team_leave")
+	author := randomString("u_auth")
+	leaving := randomString("u_leaving")
+	spare := randomString("u_spare")
+
+	sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "A", IsActive: true},
+			{UserID: leaving, Username: "L", IsActive: true},
+			{UserID: spare, Username: "S", IsActive: false},
+		},
+	})
+
+	prID := randomString("pr_leave")
+	code, _ := sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: prID, PullRequestName: "Leave", AuthorID: author,
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	soloTeam := randomString("team_solo")
+	soloAuthor := randomString("u_solo_auth")
+	soloReviewer := randomString("u_solo_rev")
+	sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: soloTeam,
+		Members: []TeamMember{
+			{UserID: soloAuthor, Username: "SA", IsActive: true},
+			{UserID: soloReviewer, Username: "SR", IsActive: true},
+		},
+	})
+
+	soloPR := randomString("pr_solo")
+	code, _ = sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: soloPR, PullRequestName: "Solo", AuthorID: soloAuthor,
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	sendRequest(t, "POST", "/users/setIsActive", map[string]interface{}{
+		"user_id": spare, "is_active": true,
+	})
+
+	type handoverResponse struct {
+		Reassigned []struct {
+			PullRequestID string `json:"pull_request_id"`
+			ReplacedBy    string `json:"replaced_by"`
+		} `json:"reassigned"`
+		NotReassigned []struct {
+			PullRequestID string `json:"pull_request_id"`
+		} `json:"not_reassigned"`
+	}
+
+	code, body := sendRequest(t, "POST", "/users/setIsActive", map[string]interface{}{
+		"user_id": leaving, "is_active": false, "reassign_reviews": true,
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	var resp handoverResponse
+	json.Unmarshal(body, &resp)
+	require.Len(t, resp.Reassigned, 1)
+	assert.Equal(t, prID, resp.Reassigned[0].PullRequestID)
+	assert.Equal(t, spare, resp.Reassigned[0].ReplacedBy)
+	assert.Empty(t, resp.NotReassigned)
+
+	code, body = sendRequest(t, "POST", "/users/setIsActive", map[string]interface{}{
+		"user_id": soloReviewer, "is_active": false, "reassign_reviews": true,
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	resp = handoverResponse{}
+	json.Unmarshal(body, &resp)
+	assert.Empty(t, resp.Reassigned)
+	require.Len(t, resp.NotReassigned, 1)
+	assert.Equal(t, soloPR, resp.NotReassigned[0].PullRequestID)
+
+	code, body = sendRequest(t, "GET", fmt.Sprintf("/users/getReview?user_id=%s", soloReviewer), nil)
+	require.Equal(t, http.StatusOK, code)
+
+	var reviews UserReviewsResponse
+	json.Unmarshal(body, &reviews)
+	require.Len(t, reviews.PullRequests, 1)
+	assert.Equal(t, soloPR, reviews.PullRequests[0].ID)
+}
+
+func TestE2E_DeactivateTeamBatch(t *testing.T) {
+	// Сценарий: команда уезжает на offsite. Её ревью переходят в резервную команду,
+	// повторный вызов ничего не ломает.
+	backup := randomString("team_backup")
+	helper := randomString("u_helper")
+	sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: backup,
+		Members:  []TeamMember{{UserID: helper, Username: "H", IsActive: true}},
+	})
+
+	teamName := randomString("team_offsite")
+	author := randomString("u_auth")
+	reviewer := randomString("u_rev")
+	sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "A", IsActive: true},
+			{UserID: reviewer, Username: "R", IsActive: true},
+		},
+		Settings: &TeamSettings{FallbackTeams: []string{backup}, MaxReviewers: 1},
+	})
+
+	prID := randomString("pr_offsite")
+	code, body := sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: prID, PullRequestName: "Offsite", AuthorID: author,
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	var pr PullRequestResponse
+	json.Unmarshal(body, &pr)
+	require.Equal(t, []string{reviewer}, pr.PR.AssignedReviewers)
+
+	type batchResponse struct {
+		Users []struct {
+			UserID   string `json:"user_id"`
+			IsActive bool   `json:"is_active"`
+		} `json:"users"`
+		PullRequests []struct {
+			PullRequestID string `json:"pull_request_id"`
+			Reassigned    []struct {
+				OldUserID  string `json:"old_user_id"`
+				ReplacedBy string `json:"replaced_by"`
+			} `json:"reassigned"`
+			NotReassigned []string `json:"not_reassigned"`
+		} `json:"pull_requests"`
+	}
+
+	code, body = sendRequest(t, "POST", "/users/deactivateBatch", map[string]interface{}{
+		"team_name": teamName,
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	var resp batchResponse
+	json.Unmarshal(body, &resp)
+	require.Len(t, resp.Users, 2)
+	for _, u := range resp.Users {
+		assert.False(t, u.IsActive)
+	}
+	require.Len(t, resp.PullRequests, 1)
+	assert.Equal(t, prID, resp.PullRequests[0].PullRequestID)
+	require.Len(t, resp.PullRequests[0].Reassigned, 1)
+	assert.Equal(t, reviewer, resp.PullRequests[0].Reassigned[0].OldUserID)
+	assert.Equal(t, helper, resp.PullRequests[0].Reassigned[0].ReplacedBy)
+	assert.Empty(t, resp.PullRequests[0].NotReassigned)
+
+	// повторный вызов: открытых ревью у команды уже нет
+	code, body = sendRequest(t, "POST", "/users/deactivateBatch", map[string]interface{}{
+		"team_name": teamName,
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	resp = batchResponse{}
+	json.Unmarshal(body, &resp)
+	assert.Len(t, resp.Users, 2)
+	assert.Empty(t, resp.PullRequests)
+
+	code, _ = sendRequest(t, "POST", "/users/deactivateBatch", map[string]interface{}{})
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestE2E_ReviewCapacity(t *testing.T) {
+	// Сценарий: единственный ревьювер может вести только одно ревью одновременно.
+	teamName := randomString("team_capacity")
+	author := randomString("u_auth")
+	senior := randomString("u_senior")
+	limit := 1
+
+	code, body := sendRequest(t, "POST", "/team/add", map[string]interface{}{
+		"team_name": teamName,
+		"members": []map[string]interface{}{
+			{"user_id": author, "username": "A", "is_active": true},
+			{"user_id": senior, "username": "S", "is_active": true, "max_open_reviews": limit},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	var team struct {
+		Members []struct {
+			UserID         string `json:"user_id"`
+			MaxOpenReviews *int   `json:"max_open_reviews"`
+		} `json:"members"`
+	}
+	json.Unmarshal(body, &team)
+	for _, m := range team.Members {
+		if m.UserID == senior {
+			require.NotNil(t, m.MaxOpenReviews)
+			assert.Equal(t, limit, *m.MaxOpenReviews)
+		}
+	}
+
+	firstPR := randomString("pr_cap1")
+	code, body = sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: firstPR, PullRequestName: "First", AuthorID: author,
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	var resp PullRequestResponse
+	json.Unmarshal(body, &resp)
+	assert.Equal(t, []string{senior}, resp.PR.AssignedReviewers)
+
+	code, body = sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: randomString("pr_cap2"), PullRequestName: "Second", AuthorID: author,
+	})
+	require.Equal(t, http.StatusConflict, code)
+
+	var errResp ErrorResponse
+	json.Unmarshal(body, &errResp)
+	assert.Equal(t, "REVIEWERS_AT_CAPACITY", errResp.Error.Code)
+
+	// повторное добавление без max_open_reviews не снимает лимит
+	code, _ = sendRequest(t, "POST", "/team/addMember", TeamRequest{
+		TeamName: teamName,
+		Members:  []TeamMember{{UserID: senior, Username: "S", IsActive: true}},
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	code, _ = sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: randomString("pr_cap2"), PullRequestName: "Second", AuthorID: author,
+	})
+	require.Equal(t, http.StatusConflict, code)
+
+	// после снятия лимита ревьювер снова доступен
+	code, _ = sendRequest(t, "POST", "/users/setMaxOpenReviews", map[string]interface{}{
+		"user_id": senior, "max_open_reviews": nil,
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	code, _ = sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: randomString("pr_cap3"), PullRequestName: "Third", AuthorID: author,
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	code, _ = sendRequest(t, "POST", "/users/setMaxOpenReviews", map[string]interface{}{
+		"user_id": senior, "max_open_reviews": -1,
+	})
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestE2E_ReviewDecisions(t *testing.T) {
+	// Сценарий: ревьювер сначала просит изменения, затем одобряет; в PR видно последнее решение
+	teamName := randomString("team_review")
+	author := randomString("u_auth")
+	reviewer := randomString("u_rev")
+	outsider := randomString("u_out")
+
+	sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "A", IsActive: true},
+			{UserID: reviewer, Username: "R", IsActive: true},
+			{UserID: outsider, Username: "O", IsActive: false},
+		},
+	})
+
+	prID := randomString("pr_review")
+	code, _ := sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: prID, PullRequestName: "Review", AuthorID: author,
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	review := func(userID, decision string) (int, []byte) {
+		return sendRequest(t, "POST", "/pullRequest/review", map[string]string{
+			"pull_request_id": prID, "reviewer_id": userID, "decision": decision,
+		})
+	}
+
+	code, _ = review(reviewer, "CHANGES_REQUESTED")
+	require.Equal(t, http.StatusOK, code)
+
+	code, body := review(reviewer, "APPROVED")
+	require.Equal(t, http.StatusOK, code)
+
+	var resp PullRequestResponse
+	json.Unmarshal(body, &resp)
+	require.Len(t, resp.PR.ReviewDecisions, 1)
+	assert.Equal(t, reviewer, resp.PR.ReviewDecisions[0].UserID)
+	assert.Equal(t, "APPROVED", resp.PR.ReviewDecisions[0].Decision)
+
+	var errResp ErrorResponse
+	code, body = review(outsider, "APPROVED")
+	require.Equal(t, http.StatusConflict, code)
+	json.Unmarshal(body, &errResp)
+	assert.Equal(t, "NOT_ASSIGNED", errResp.Error.Code)
+
+	code, _ = review(reviewer, "LGTM")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	sendRequest(t, "POST", "/pullRequest/merge", PullRequestMergeReq{PullRequestID: prID})
+
+	code, body = review(reviewer, "COMMENTED")
+	require.Equal(t, http.StatusConflict, code)
+	json.Unmarshal(body, &errResp)
+	assert.Equal(t, "PR_MERGED", errResp.Error.Code)
+}
+
+func TestE2E_ReviewDecisionsAssignmentPeriod(t *testing.T) {
+	// Тест: решение ревьювера не переживает его снятие, после повторного назначения решений у него нет
+	teamName := randomString("team_review_period")
+	author := randomString("u_auth")
+
+	code, _ := sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "A", IsActive: true, Role: "LEAD"},
+			{UserID: randomString("u_rev1"), Username: "R1", IsActive: true},
+			{UserID: randomString("u_rev2"), Username: "R2", IsActive: true},
+		},
+		Settings: &TeamSettings{MaxReviewers: 1},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	prID := randomString("pr_review_period")
+	code, body := sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: prID, PullRequestName: "Review period", AuthorID: author,
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	var resp PullRequestResponse
+	json.Unmarshal(body, &resp)
+	require.Len(t, resp.PR.AssignedReviewers, 1)
+	first := resp.PR.AssignedReviewers[0]
+
+	code, _ = sendRequest(t, "POST", "/pullRequest/review", map[string]string{
+		"pull_request_id": prID, "reviewer_id": first, "decision": "APPROVED",
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	code, body = sendRequest(t, "POST", "/pullRequest/reassign", PullRequestReassignReq{
+		PullRequestID: prID, OldUserID: first, Actor: author,
+	})
+	require.Equal(t, http.StatusOK, code)
+	resp = PullRequestResponse{}
+	json.Unmarshal(body, &resp)
+	assert.Empty(t, resp.PR.ReviewDecisions)
+	second := resp.ReplacedBy
+
+	code, _ = sendRequest(t, "POST", "/pullRequest/removeReviewer", map[string]string{
+		"pull_request_id": prID, "user_id": second, "actor": author,
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	code, body = sendRequest(t, "POST", "/pullRequest/addReviewer", map[string]string{
+		"pull_request_id": prID, "user_id": first, "actor": author,
+	})
+	require.Equal(t, http.StatusOK, code)
+	resp = PullRequestResponse{}
+	json.Unmarshal(body, &resp)
+	assert.Equal(t, []string{first}, resp.PR.AssignedReviewers)
+	assert.Empty(t, resp.PR.ReviewDecisions)
+
+	code, body = sendRequest(t, "POST", "/pullRequest/review", map[string]string{
+		"pull_request_id": prID, "reviewer_id": first, "decision": "COMMENTED",
+	})
+	require.Equal(t, http.StatusOK, code)
+	resp = PullRequestResponse{}
+	json.Unmarshal(body, &resp)
+	require.Len(t, resp.PR.ReviewDecisions, 1)
+	assert.Equal(t, "COMMENTED", resp.PR.ReviewDecisions[0].Decision)
+}
+
+func TestE2E_PullRequestLifecycle(t *testing.T) {
+	// Сценарий: черновик -> готов -> закрыт -> переоткрыт с теми же ревьюверами -> смержен
+	teamName := randomString("team_lifecycle")
+	author := randomString("u_auth")
+	reviewer := randomString("u_rev")
+
+	sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "A", IsActive: true},
+			{UserID: reviewer, Username: "R", IsActive: true},
+		},
+	})
+
+	prID := randomString("pr_lifecycle")
+	code, body := sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: prID, PullRequestName: "Lifecycle", AuthorID: author, Draft: true,
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	var resp PullRequestResponse
+	json.Unmarshal(body, &resp)
+	assert.Equal(t, "DRAFT", resp.PR.Status)
+	assert.Empty(t, resp.PR.AssignedReviewers)
+
+	transition := func(action string) (int, PullRequestResponse) {
+		code, body := sendRequest(t, "POST", "/pullRequest/"+action, PullRequestMergeReq{PullRequestID: prID})
+		var resp PullRequestResponse
+		json.Unmarshal(body, &resp)
+		return code, resp
+	}
+
+	// решения и переназначения возможны только у OPEN PR
+	assertNotOpen := func() {
+		var errResp ErrorResponse
+		code, body := sendRequest(t, "POST", "/pullRequest/review", map[string]string{
+			"pull_request_id": prID, "reviewer_id": reviewer, "decision": "APPROVED",
+		})
+		assert.Equal(t, http.StatusConflict, code)
+		json.Unmarshal(body, &errResp)
+		assert.Equal(t, "PR_NOT_REVIEWABLE", errResp.Error.Code)
+
+		code, body = sendRequest(t, "POST", "/pullRequest/reassign", PullRequestReassignReq{
+			PullRequestID: prID, OldUserID: reviewer,
+		})
+		assert.Equal(t, http.StatusConflict, code)
+		json.Unmarshal(body, &errResp)
+		assert.Equal(t, "PR_NOT_REVIEWABLE", errResp.Error.Code)
+	}
+
+	assertNotOpen()
+
+	code, _ = transition("merge")
+	assert.Equal(t, http.StatusConflict, code)
+
+	code, resp = transition("ready")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "OPEN", resp.PR.Status)
+	assert.Equal(t, []string{reviewer}, resp.PR.AssignedReviewers)
+
+	code, _ = transition("ready")
+	assert.Equal(t, http.StatusConflict, code)
+
+	code, resp = transition("close")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "CLOSED", resp.PR.Status)
+	assert.Empty(t, resp.PR.AssignedReviewers)
+	assertNotOpen()
+
+	code, body = sendRequest(t, "GET", fmt.Sprintf("/users/getReview?user_id=%s", reviewer), nil)
+	require.Equal(t, http.StatusOK, code)
+	var reviews UserReviewsResponse
+	json.Unmarshal(body, &reviews)
+	assert.Empty(t, reviews.PullRequests)
+
+	code, resp = transition("reopen")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "OPEN", resp.PR.Status)
+	assert.Equal(t, []string{reviewer}, resp.PR.AssignedReviewers)
+
+	code, _ = transition("merge")
+	require.Equal(t, http.StatusOK, code)
+
+	code, body = sendRequest(t, "POST", "/pullRequest/reopen", PullRequestMergeReq{PullRequestID: prID})
+	require.Equal(t, http.StatusConflict, code)
+	var errResp ErrorResponse
+	json.Unmarshal(body, &errResp)
+	assert.Equal(t, "INVALID_STATUS_TRANSITION", errResp.Error.Code)
+}
+
+func TestE2E_MergePolicy(t *testing.T) {
+	// Сценарий: команда требует одно одобрение и не пускает PR с запрошенными изменениями
+	teamName := randomString("team_policy")
+	author := randomString("u_auth")
+	reviewer := randomString("u_rev")
+
+	settings := &TeamSettings{
+		MaxReviewers: 1,
+		MergePolicy:  &MergePolicy{RequiredApprovals: 1, BlockChangesRequested: true},
+	}
+
+	sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "A", IsActive: true},
+			{UserID: reviewer, Username: "R", IsActive: true},
+		},
+		Settings: settings,
+	})
+
+	prID := randomString("pr_policy")
+	code, _ := sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: prID, PullRequestName: "Policy", AuthorID: author,
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	sendRequest(t, "POST", "/pullRequest/review", map[string]string{
+		"pull_request_id": prID, "reviewer_id": reviewer, "decision": "CHANGES_REQUESTED",
+	})
+
+	code, body := sendRequest(t, "POST", "/pullRequest/merge", PullRequestMergeReq{PullRequestID: prID})
+	require.Equal(t, http.StatusConflict, code)
+
+	var errResp ErrorResponse
+	json.Unmarshal(body, &errResp)
+	assert.Equal(t, "MERGE_BLOCKED", errResp.Error.Code)
+	assert.Len(t, errResp.Error.Details, 2)
+
+	sendRequest(t, "POST", "/pullRequest/review", map[string]string{
+		"pull_request_id": prID, "reviewer_id": reviewer, "decision": "APPROVED",
+	})
+
+	code, body = sendRequest(t, "POST", "/pullRequest/merge", PullRequestMergeReq{PullRequestID: prID})
+	require.Equal(t, http.StatusOK, code)
+
+	var resp PullRequestResponse
+	json.Unmarshal(body, &resp)
+	assert.Equal(t, "MERGED", resp.PR.Status)
+
+	// принудительное слияние без одобрений
+	forcedID := randomString("pr_forced")
+	sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: forcedID, PullRequestName: "Forced", AuthorID: author,
+	})
+
+	code, _ = sendRequest(t, "POST", "/pullRequest/merge", PullRequestMergeReq{PullRequestID: forcedID, Force: true})
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// принудительно сливать могут только админы (ADMIN_USER_IDS) и MAINTAINER/LEAD команды
+	for _, actor := range []string{randomString("u_nobody"), author} {
+		code, body = sendRequest(t, "POST", "/pullRequest/merge", PullRequestMergeReq{
+			PullRequestID: forcedID, Force: true, Actor: actor,
+		})
+		assert.Equal(t, http.StatusForbidden, code)
+		json.Unmarshal(body, &errResp)
+		assert.Equal(t, "FORBIDDEN", errResp.Error.Code)
+	}
+
+	code, _ = sendRequest(t, "POST", "/pullRequest/merge", PullRequestMergeReq{
+		PullRequestID: forcedID, Force: true, Actor: "e2e-admin",
+	})
+	assert.Equal(t, http.StatusOK, code)
+
+	lead := randomString("u_lead")
+	code, _ = sendRequest(t, "POST", "/team/addMember", TeamRequest{
+		TeamName: teamName,
+		Members:  []TeamMember{{UserID: lead, Username: "L", IsActive: true, Role: "LEAD"}},
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	leadForcedID := randomString("pr_forced")
+	sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: leadForcedID, PullRequestName: "Forced by lead", AuthorID: author,
+	})
+	code, _ = sendRequest(t, "POST", "/pullRequest/merge", PullRequestMergeReq{
+		PullRequestID: leadForcedID, Force: true, Actor: lead,
+	})
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func TestE2E_ListPullRequests(t *testing.T) {
+	// Сценарий: у автора три PR, листаем их по одному, фильтруем по статусу и названию
+	teamName := randomString("team_list")
+	author := randomString("u_auth")
+	reviewer := randomString("u_rev")
+
+	sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "A", IsActive: true},
+			{UserID: reviewer, Username: "R", IsActive: true},
+		},
+	})
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		prID := randomString("pr_list")
+		ids = append(ids, prID)
+		code, _ := sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+			PullRequestID: prID, PullRequestName: fmt.Sprintf("List feature %d", i), AuthorID: author,
+		})
+		require.Equal(t, http.StatusCreated, code)
+	}
+	sendRequest(t, "POST", "/pullRequest/merge", PullRequestMergeReq{PullRequestID: ids[0]})
+
+	type listResponse struct {
+		PullRequests []struct {
+			ID                string   `json:"pull_request_id"`
+			Status            string   `json:"status"`
+			AssignedReviewers []string `json:"assigned_reviewers"`
+		} `json:"pull_requests"`
+		NextCursor string `json:"next_cursor"`
+	}
+
+	list := func(query string) listResponse {
+		code, body := sendRequest(t, "GET", "/pullRequest/list?"+query, nil)
+		require.Equal(t, http.StatusOK, code)
+		var resp listResponse
+		json.Unmarshal(body, &resp)
+		return resp
+	}
+
+	// постранично, от старых к новым
+	var got []string
+	cursor := ""
+	for page := 0; page < 4; page++ {
+		resp := list(fmt.Sprintf("author_id=%s&order=asc&limit=1&cursor=%s", author, cursor))
+		for _, pr := range resp.PullRequests {
+			got = append(got, pr.ID)
+		}
+		cursor = resp.NextCursor
+		if cursor == "" {
+			break
+		}
+	}
+	assert.Equal(t, ids, got)
+
+	resp := list(fmt.Sprintf("team_name=%s&status=OPEN", teamName))
+	assert.Len(t, resp.PullRequests, 2)
+
+	resp = list(fmt.Sprintf("reviewer_id=%s&name=feature%%201", reviewer))
+	require.Len(t, resp.PullRequests, 1)
+	assert.Equal(t, ids[1], resp.PullRequests[0].ID)
+
+	code, _ := sendRequest(t, "GET", "/pullRequest/list?status=UNKNOWN", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestE2E_GetPullRequest(t *testing.T) {
+	// Тест на получение PR по id вместе с именами и командами ревьюверов
+	teamName := randomString("team_get_pr")
+	author := randomString("u_auth")
+	reviewer := randomString("u_rev")
+
+	sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "Author", IsActive: true},
+			{UserID: reviewer, Username: "Reviewer", IsActive: true},
+		},
+	})
+
+	prID := randomString("pr_get")
+	code, _ := sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: prID, PullRequestName: "Get me", AuthorID: author,
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	code, body := sendRequest(t, "GET", fmt.Sprintf("/pullRequest/get?pull_request_id=%s", prID), nil)
+	require.Equal(t, http.StatusOK, code)
+
+	var resp PullRequestResponse
+	json.Unmarshal(body, &resp)
+	assert.Equal(t, prID, resp.PR.ID)
+	assert.Equal(t, "OPEN", resp.PR.Status)
+	assert.NotNil(t, resp.PR.CreatedAt)
+	assert.Nil(t, resp.PR.MergedAt)
+	assert.Equal(t, []string{reviewer}, resp.PR.AssignedReviewers)
+	require.Len(t, resp.PR.Reviewers, 1)
+	assert.Equal(t, reviewer, resp.PR.Reviewers[0].UserID)
+	assert.Equal(t, "Reviewer", resp.PR.Reviewers[0].Username)
+	assert.Equal(t, teamName, resp.PR.Reviewers[0].TeamName)
+
+	code, body = sendRequest(t, "GET", "/pullRequest/get?pull_request_id="+randomString("pr_missing"), nil)
+	require.Equal(t, http.StatusNotFound, code)
+
+	var errResp ErrorResponse
+	json.Unmarshal(body, &errResp)
+	assert.Equal(t, "NOT_FOUND", errResp.Error.Code)
+}
+
+func TestE2E_PullRequestHistory(t *testing.T) {
+	// Тест на историю PR: создание, назначение, переназначение и слияние пишутся по порядку
+	teamName := randomString("team_history")
+	author := randomString("u_auth")
+	rev1 := randomString("u_rev1")
+	rev2 := randomString("u_rev2")
+
+	sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "Author", IsActive: true},
+			{UserID: rev1, Username: "R1", IsActive: true},
+			{UserID: rev2, Username: "R2", IsActive: true},
+		},
+		Settings: &TeamSettings{MaxReviewers: 1},
+	})
+
+	prID := randomString("pr_history")
+	code, body := sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: prID, PullRequestName: "History", AuthorID: author,
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	var createResp PullRequestResponse
+	json.Unmarshal(body, &createResp)
+	require.Len(t, createResp.PR.AssignedReviewers, 1)
+	oldReviewer := createResp.PR.AssignedReviewers[0]
+
+	code, body = sendRequest(t, "POST", "/pullRequest/reassign", PullRequestReassignReq{
+		PullRequestID: prID, OldUserID: oldReviewer, Actor: author,
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	var reassignResp PullRequestResponse
+	json.Unmarshal(body, &reassignResp)
+
+	code, _ = sendRequest(t, "POST", "/pullRequest/merge", PullRequestMergeReq{PullRequestID: prID})
+	require.Equal(t, http.StatusOK, code)
+
+	code, body = sendRequest(t, "GET", "/pullRequest/history?pull_request_id="+prID, nil)
+	require.Equal(t, http.StatusOK, code)
+
+	var history struct {
+		PullRequestID string `json:"pull_request_id"`
+		Events        []struct {
+			Type       string `json:"type"`
+			Actor      string `json:"actor"`
+			ReviewerID string `json:"reviewer_id"`
+			ReplacedBy string `json:"replaced_by"`
+			ToStatus   string `json:"to_status"`
+		} `json:"events"`
+	}
+	json.Unmarshal(body, &history)
+	assert.Equal(t, prID, history.PullRequestID)
+	require.Len(t, history.Events, 4)
+
+	assert.Equal(t, "CREATED", history.Events[0].Type)
+	assert.Equal(t, author, history.Events[0].Actor)
+	assert.Equal(t, "OPEN", history.Events[0].ToStatus)
+	assert.Equal(t, "REVIEWER_ASSIGNED", history.Events[1].Type)
+	assert.Equal(t, oldReviewer, history.Events[1].ReviewerID)
+	assert.Equal(t, "REVIEWER_REASSIGNED", history.Events[2].Type)
+	assert.Equal(t, author, history.Events[2].Actor)
+	assert.Equal(t, oldReviewer, history.Events[2].ReviewerID)
+	assert.Equal(t, reassignResp.ReplacedBy, history.Events[2].ReplacedBy)
+	assert.Equal(t, "MERGED", history.Events[3].Type)
+	assert.Equal(t, "MERGED", history.Events[3].ToStatus)
+
+	code, _ = sendRequest(t, "GET", "/pullRequest/history?pull_request_id="+randomString("pr_missing"), nil)
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestE2E_ManualReviewers(t *testing.T) {
+	// Тест на ручное добавление эксперта из другой команды и снятие ревьювера без замены,
+	// в .env.test включено ACCESS_RESTRICT_REVIEWER_EDITS: менять ревьюверов вручную может только
+	// MAINTAINER/LEAD команды PR или админ
+	teamName := randomString("team_manual")
+	expertTeam := randomString("team_expert")
+	author := randomString("u_auth")
+	reviewer := randomString("u_rev")
+	expert := randomString("u_expert")
+	retired := randomString("u_retired")
+
+	sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "Author", IsActive: true, Role: "LEAD"},
+			{UserID: reviewer, Username: "Reviewer", IsActive: true},
+		},
+	})
+	sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: expertTeam,
+		Members: []TeamMember{
+			{UserID: expert, Username: "Expert", IsActive: true},
+			{UserID: retired, Username: "Retired", IsActive: false},
+		},
+	})
+
+	prID := randomString("pr_manual")
+	code, _ := sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: prID, PullRequestName: "Manual", AuthorID: author,
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	type reviewerReq struct {
+		PullRequestID string `json:"pull_request_id"`
+		UserID        string `json:"user_id"`
+		Actor         string `json:"actor,omitempty"`
+	}
+
+	var errResp ErrorResponse
+	for _, actor := range []string{"", reviewer, expert} {
+		code, body := sendRequest(t, "POST", "/pullRequest/addReviewer", reviewerReq{PullRequestID: prID, UserID: expert, Actor: actor})
+		assert.Equal(t, http.StatusForbidden, code)
+		json.Unmarshal(body, &errResp)
+		assert.Equal(t, "FORBIDDEN", errResp.Error.Code)
+	}
+
+	code, body := sendRequest(t, "POST", "/pullRequest/addReviewer", reviewerReq{PullRequestID: prID, UserID: retired, Actor: author})
+	assert.Equal(t, http.StatusConflict, code)
+	json.Unmarshal(body, &errResp)
+	assert.Equal(t, "REVIEWER_UNAVAILABLE", errResp.Error.Code)
+
+	code, body = sendRequest(t, "POST", "/pullRequest/addReviewer", reviewerReq{PullRequestID: prID, UserID: expert, Actor: author})
+	require.Equal(t, http.StatusOK, code)
+
+	var resp PullRequestResponse
+	json.Unmarshal(body, &resp)
+	assert.Equal(t, []string{reviewer, expert}, resp.PR.AssignedReviewers)
+
+	code, body = sendRequest(t, "POST", "/pullRequest/addReviewer", reviewerReq{PullRequestID: prID, UserID: expert, Actor: author})
+	assert.Equal(t, http.StatusConflict, code)
+	json.Unmarshal(body, &errResp)
+	assert.Equal(t, "ALREADY_ASSIGNED", errResp.Error.Code)
+
+	code, _ = sendRequest(t, "POST", "/pullRequest/addReviewer", reviewerReq{PullRequestID: prID, UserID: author, Actor: author})
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = sendRequest(t, "POST", "/pullRequest/addReviewer", reviewerReq{PullRequestID: prID, UserID: randomString("u_missing"), Actor: author})
+	assert.Equal(t, http.StatusNotFound, code)
+
+	code, _ = sendRequest(t, "POST", "/pullRequest/removeReviewer", reviewerReq{PullRequestID: prID, UserID: reviewer})
+	assert.Equal(t, http.StatusForbidden, code)
+
+	code, _ = sendRequest(t, "POST", "/pullRequest/removeReviewer", reviewerReq{PullRequestID: prID, UserID: reviewer, Actor: "system"})
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, body = sendRequest(t, "POST", "/pullRequest/removeReviewer", reviewerReq{PullRequestID: prID, UserID: reviewer, Actor: "e2e-admin"})
+	require.Equal(t, http.StatusOK, code)
+	resp = PullRequestResponse{}
+	json.Unmarshal(body, &resp)
+	assert.Equal(t, []string{expert}, resp.PR.AssignedReviewers)
+
+	code, _ = sendRequest(t, "POST", "/pullRequest/merge", PullRequestMergeReq{PullRequestID: prID})
+	require.Equal(t, http.StatusOK, code)
+
+	code, body = sendRequest(t, "POST", "/pullRequest/removeReviewer", reviewerReq{PullRequestID: prID, UserID: expert, Actor: author})
+	assert.Equal(t, http.StatusConflict, code)
+	json.Unmarshal(body, &errResp)
+	assert.Equal(t, "PR_MERGED", errResp.Error.Code)
+}
+
+func TestE2E_StaleReviewPolicy(t *testing.T) {
+	// Тест на настройку политики зависших ревью: политика сохраняется, неизвестное действие отклоняется
+	teamName := randomString("team_stale")
+	lead := randomString("u_lead")
+
+	code, _ := sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members:  []TeamMember{{UserID: lead, Username: "Lead", IsActive: true, Role: "LEAD"}},
+		Settings: &TeamSettings{StaleReview: &StaleReview{Action: "ESCALATE"}},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	code, body := sendRequest(t, "GET", "/team/get?team_name="+teamName, nil)
+	require.Equal(t, http.StatusOK, code)
+
+	var team struct {
+		Settings TeamSettings `json:"settings"`
+	}
+	json.Unmarshal(body, &team)
+	require.NotNil(t, team.Settings.StaleReview)
+	assert.Equal(t, "ESCALATE", team.Settings.StaleReview.Action)
+
+	code, _ = sendRequest(t, "POST", "/team/setSettings", TeamSettingsRequest{
+		TeamName: teamName,
+		Settings: TeamSettings{StaleReview: &StaleReview{Action: "SOMETIMES"}},
+	})
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func scanStale(t *testing.T, req StaleScanReq) StaleScanResponse {
+	code, body := sendRequest(t, "POST", "/pullRequest/scanStale", req)
+	require.Equal(t, http.StatusOK, code)
+
+	var resp StaleScanResponse
+	require.NoError(t, json.Unmarshal(body, &resp))
+	return resp
+}
+
+func countEvents(t *testing.T, prID, eventType string) int {
+	code, body := sendRequest(t, "GET", "/pullRequest/history?pull_request_id="+prID, nil)
+	require.Equal(t, http.StatusOK, code)
+
+	var history PRHistoryResponse
+	json.Unmarshal(body, &history)
+
+	n := 0
+	for _, e := range history.Events {
+		if e.Type == eventType {
+			n++
+		}
+	}
+	return n
+}
+
+func TestE2E_StaleReviewReassign(t *testing.T) {
+	// Тест на обработку зависшего ревью: dry run ничего не меняет, REASSIGN заменяет ревьювера от имени system
+	teamName := randomString("team_stale_reassign")
+	author := randomString("u_auth")
+	rev1 := randomString("u_rev1")
+	rev2 := randomString("u_rev2")
+
+	code, _ := sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "Author", IsActive: true},
+			{UserID: rev1, Username: "R1", IsActive: true},
+			{UserID: rev2, Username: "R2", IsActive: true},
+		},
+		Settings: &TeamSettings{MaxReviewers: 1, StaleReview: &StaleReview{Action: "REASSIGN"}},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	prID := randomString("pr_stale")
+	code, body := sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: prID, PullRequestName: "Stale", AuthorID: author,
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	var resp PullRequestResponse
+	json.Unmarshal(body, &resp)
+	require.Len(t, resp.PR.AssignedReviewers, 1)
+	stale := resp.PR.AssignedReviewers[0]
+
+	// Момент до создания PR: ревью ещё не зависло
+	assert.Empty(t, scanStale(t, StaleScanReq{Before: time.Now().Add(-time.Minute), PullRequestID: prID}).StaleReviews)
+
+	before := time.Now().Add(time.Minute)
+	planned := scanStale(t, StaleScanReq{Before: before, PullRequestID: prID, DryRun: true})
+	require.Len(t, planned.StaleReviews, 1)
+	assert.Equal(t, stale, planned.StaleReviews[0].ReviewerID)
+	assert.Equal(t, "REASSIGN", planned.StaleReviews[0].Action)
+	assert.Equal(t, 0, countEvents(t, prID, "REVIEW_STALE"))
+
+	code, body = sendRequest(t, "GET", "/pullRequest/get?pull_request_id="+prID, nil)
+	require.Equal(t, http.StatusOK, code)
+	resp = PullRequestResponse{}
+	json.Unmarshal(body, &resp)
+	assert.Equal(t, []string{stale}, resp.PR.AssignedReviewers)
+
+	handled := scanStale(t, StaleScanReq{Before: before, PullRequestID: prID})
+	require.Len(t, handled.StaleReviews, 1)
+	target := handled.StaleReviews[0].Target
+	assert.NotEmpty(t, target)
+	assert.NotEqual(t, stale, target)
+
+	code, body = sendRequest(t, "GET", "/pullRequest/get?pull_request_id="+prID, nil)
+	require.Equal(t, http.StatusOK, code)
+	resp = PullRequestResponse{}
+	json.Unmarshal(body, &resp)
+	assert.Equal(t, []string{target}, resp.PR.AssignedReviewers)
+
+	code, body = sendRequest(t, "GET", "/pullRequest/history?pull_request_id="+prID, nil)
+	require.Equal(t, http.StatusOK, code)
+	var history PRHistoryResponse
+	json.Unmarshal(body, &history)
+
+	var reassigned, flagged bool
+	for _, e := range history.Events {
+		switch e.Type {
+		case "REVIEWER_REASSIGNED":
+			reassigned = true
+			assert.Equal(t, "system", e.Actor)
+			assert.Equal(t, stale, e.ReviewerID)
+			assert.Equal(t, target, e.ReplacedBy)
+		case "REVIEW_STALE":
+			flagged = true
+			assert.Equal(t, stale, e.ReviewerID)
+		}
+	}
+	assert.True(t, reassigned)
+	assert.True(t, flagged)
+
+	code, _ = sendRequest(t, "POST", "/pullRequest/scanStale", StaleScanReq{PullRequestID: prID})
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestE2E_StaleReviewEscalate(t *testing.T) {
+	// Тест: при ESCALATE лид команды назначается дополнительным ревьювером, зависший ревьювер остаётся
+	teamName := randomString("team_stale_escalate")
+	author := randomString("u_auth")
+	rev := randomString("u_rev")
+	lead := randomString("u_lead")
+
+	code, _ := sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "Author", IsActive: true},
+			{UserID: rev, Username: "R", IsActive: true},
+		},
+		Settings: &TeamSettings{MaxReviewers: 1, StaleReview: &StaleReview{Action: "ESCALATE"}},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	prID := randomString("pr_stale")
+	code, _ = sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: prID, PullRequestName: "Stale", AuthorID: author,
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	code, _ = sendRequest(t, "POST", "/team/addMember", TeamRequest{
+		TeamName: teamName,
+		Members:  []TeamMember{{UserID: lead, Username: "Lead", IsActive: true, Role: "LEAD"}},
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	before := time.Now().Add(time.Minute)
+	planned := scanStale(t, StaleScanReq{Before: before, PullRequestID: prID, DryRun: true})
+	require.Len(t, planned.StaleReviews, 1)
+	assert.Equal(t, "ESCALATE", planned.StaleReviews[0].Action)
+	assert.Equal(t, lead, planned.StaleReviews[0].Target)
+
+	handled := scanStale(t, StaleScanReq{Before: before, PullRequestID: prID})
+	require.Len(t, handled.StaleReviews, 1)
+	assert.Equal(t, rev, handled.StaleReviews[0].ReviewerID)
+	assert.Equal(t, lead, handled.StaleReviews[0].Target)
+
+	code, body := sendRequest(t, "GET", "/pullRequest/get?pull_request_id="+prID, nil)
+	require.Equal(t, http.StatusOK, code)
+	var resp PullRequestResponse
+	json.Unmarshal(body, &resp)
+	assert.ElementsMatch(t, []string{rev, lead}, resp.PR.AssignedReviewers)
+	assert.Equal(t, 1, countEvents(t, prID, "REVIEW_STALE"))
+}
+
+func TestE2E_StaleReviewFlaggedOnce(t *testing.T) {
+	// Тест: следующий проход с более поздним before не отмечает зависшее ревью повторно
+	// и не эскалирует его снова, отметка REVIEW_STALE не считается активностью ревьювера
+	teamName := randomString("team_stale_once")
+	author := randomString("u_auth")
+	rev := randomString("u_rev")
+	lead := randomString("u_lead")
+
+	code, _ := sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "Author", IsActive: true},
+			{UserID: rev, Username: "R", IsActive: true},
+		},
+		Settings: &TeamSettings{MaxReviewers: 1, StaleReview: &StaleReview{Action: "ESCALATE"}},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	prID := randomString("pr_stale")
+	code, _ = sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: prID, PullRequestName: "Stale", AuthorID: author,
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	code, _ = sendRequest(t, "POST", "/team/addMember", TeamRequest{
+		TeamName: teamName,
+		Members:  []TeamMember{{UserID: lead, Username: "Lead", IsActive: true, Role: "LEAD"}},
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	first := scanStale(t, StaleScanReq{Before: time.Now().Add(time.Minute), PullRequestID: prID})
+	require.Len(t, first.StaleReviews, 1)
+	assert.Equal(t, rev, first.StaleReviews[0].ReviewerID)
+	assert.Equal(t, lead, first.StaleReviews[0].Target)
+
+	second := scanStale(t, StaleScanReq{Before: time.Now().Add(2 * time.Minute), PullRequestID: prID})
+	for _, r := range second.StaleReviews {
+		assert.NotEqual(t, rev, r.ReviewerID)
+	}
+
+	code, body := sendRequest(t, "GET", "/pullRequest/history?pull_request_id="+prID, nil)
+	require.Equal(t, http.StatusOK, code)
+	var history PRHistoryResponse
+	json.Unmarshal(body, &history)
+
+	flagged, escalated := 0, 0
+	for _, e := range history.Events {
+		switch {
+		case e.Type == "REVIEW_STALE" && e.ReviewerID == rev:
+			flagged++
+		case e.Type == "REVIEWER_ASSIGNED" && e.ReviewerID == lead:
+			escalated++
+		}
+	}
+	assert.Equal(t, 1, flagged)
+	assert.Equal(t, 1, escalated)
+}
+
+func TestE2E_StaleReviewConcurrentScans(t *testing.T) {
+	// Тест: два одновременных прохода обрабатывают зависшее ревью ровно один раз
+	teamName := randomString("team_stale_concurrent")
+	author := randomString("u_auth")
+	rev := randomString("u_rev")
+
+	code, _ := sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "Author", IsActive: true},
+			{UserID: rev, Username: "R", IsActive: true},
+		},
+		Settings: &TeamSettings{MaxReviewers: 1},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	prID := randomString("pr_stale")
+	code, _ = sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: prID, PullRequestName: "Stale", AuthorID: author,
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	// ревьювер назначен раньше before
+	time.Sleep(1500 * time.Millisecond)
+	payload, err := json.Marshal(StaleScanReq{Before: time.Now().Add(-750 * time.Millisecond), PullRequestID: prID})
+	require.NoError(t, err)
+
+	const scans = 2
+	results := make(chan StaleScanResponse, scans)
+	errs := make(chan error, scans)
+	for i := 0; i < scans; i++ {
+		go func() {
+			resp, err := http.Post(baseURL+"/pullRequest/scanStale", "application/json", bytes.NewReader(payload))
+			if err != nil {
+				errs <- err
+				return
+			}
+			defer resp.Body.Close()
+
+			var scan StaleScanResponse
+			if resp.StatusCode != http.StatusOK {
+				errs <- fmt.Errorf("unexpected status %d", resp.StatusCode)
+				return
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&scan); err != nil {
+				errs <- err
+				return
+			}
+			results <- scan
+		}()
+	}
+
+	handled := 0
+	for i := 0; i < scans; i++ {
+		select {
+		case err := <-errs:
+			t.Fatal(err)
+		case scan := <-results:
+			handled += len(scan.StaleReviews)
+		}
+	}
+	assert.Equal(t, 1, handled)
+	assert.Equal(t, 1, countEvents(t, prID, "REVIEW_STALE"))
+}
+
+func TestE2E_ConcurrentAddReviewer(t *testing.T) {
+	// Тест: одновременные addReviewer не превышают max_reviewers, строка PR блокируется на время изменения
+	teamName := randomString("team_concurrent_add")
+	expertTeam := randomString("team_concurrent_experts")
+	author := randomString("u_auth")
+
+	code, _ := sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members:  []TeamMember{{UserID: author, Username: "Author", IsActive: true}},
+		Settings: &TeamSettings{MaxReviewers: 2},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	const adds = 5
+	experts := make([]TeamMember, 0, adds)
+	for i := 0; i < adds; i++ {
+		experts = append(experts, TeamMember{UserID: randomString("u_expert"), Username: "E", IsActive: true})
+	}
+	code, _ = sendRequest(t, "POST", "/team/add", TeamRequest{TeamName: expertTeam, Members: experts})
+	require.Equal(t, http.StatusCreated, code)
+
+	prID := randomString("pr_concurrent_add")
+	code, _ = sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: prID, PullRequestName: "Concurrent add", AuthorID: author,
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	statuses := make(chan int, adds)
+	errs := make(chan error, adds)
+	for _, expert := range experts {
+		payload, err := json.Marshal(map[string]string{
+			"pull_request_id": prID, "user_id": expert.UserID, "actor": "e2e-admin",
+		})
+		require.NoError(t, err)
+
+		go func() {
+			resp, err := http.Post(baseURL+"/pullRequest/addReviewer", "application/json", bytes.NewReader(payload))
+			if err != nil {
+				errs <- err
+				return
+			}
+			resp.Body.Close()
+			statuses <- resp.StatusCode
+		}()
+	}
+
+	added := 0
+	for i := 0; i < adds; i++ {
+		select {
+		case err := <-errs:
+			t.Fatal(err)
+		case status := <-statuses:
+			if status == http.StatusOK {
+				added++
+			} else {
+				assert.Equal(t, http.StatusConflict, status)
+			}
+		}
+	}
+	assert.Equal(t, 2, added)
+
+	code, body := sendRequest(t, "GET", "/pullRequest/get?pull_request_id="+prID, nil)
+	require.Equal(t, http.StatusOK, code)
+	var resp PullRequestResponse
+	json.Unmarshal(body, &resp)
+	assert.Len(t, resp.PR.AssignedReviewers, 2)
+}
+
+func TestE2E_ReassignNoPingPong(t *testing.T) {
+	// Тест: снятый ревьювер не возвращается на PR при следующем переназначении
+	teamName := randomString("team_pingpong")
+	author := randomString("u_auth")
+	rev1 := randomString("u_rev1")
+	rev2 := randomString("u_rev2")
+
+	sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "Author", IsActive: true},
+			{UserID: rev1, Username: "R1", IsActive: true},
+			{UserID: rev2, Username: "R2", IsActive: true},
+		},
+		Settings: &TeamSettings{MaxReviewers: 1},
+	})
+
+	prID := randomString("pr_pingpong")
+	code, body := sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: prID, PullRequestName: "Ping pong", AuthorID: author,
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	var resp PullRequestResponse
+	json.Unmarshal(body, &resp)
+	require.Len(t, resp.PR.AssignedReviewers, 1)
+	first := resp.PR.AssignedReviewers[0]
+
+	code, body = sendRequest(t, "POST", "/pullRequest/reassign", PullRequestReassignReq{PullRequestID: prID, OldUserID: first})
+	require.Equal(t, http.StatusOK, code)
+	resp = PullRequestResponse{}
+	json.Unmarshal(body, &resp)
+	second := resp.ReplacedBy
+	assert.NotEqual(t, first, second)
+
+	code, body = sendRequest(t, "POST", "/pullRequest/reassign", PullRequestReassignReq{PullRequestID: prID, OldUserID: second})
+	assert.Equal(t, http.StatusConflict, code)
+
+	var errResp ErrorResponse
+	json.Unmarshal(body, &errResp)
+	assert.Equal(t, "NO_CANDIDATE", errResp.Error.Code)
+}
+
+func TestE2E_ClosedReviewerNotExcluded(t *testing.T) {
+	// Тест: ревьювер, освобождённый закрытием PR и не восстановленный при переоткрытии,
+	// не считается снятым и может быть выбран снова
+	teamName := randomString("team_close_release")
+	author := randomString("u_auth")
+	rev1 := randomString("u_rev1")
+	rev2 := randomString("u_rev2")
+
+	code, _ := sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "Author", IsActive: true},
+			{UserID: rev1, Username: "R1", IsActive: true},
+			{UserID: rev2, Username: "R2", IsActive: true},
+		},
+		Settings: &TeamSettings{MaxReviewers: 1},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	prID := randomString("pr_close_release")
+	code, body := sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: prID, PullRequestName: "Close release", AuthorID: author,
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	var resp PullRequestResponse
+	json.Unmarshal(body, &resp)
+	require.Len(t, resp.PR.AssignedReviewers, 1)
+	released := resp.PR.AssignedReviewers[0]
+
+	code, _ = sendRequest(t, "POST", "/pullRequest/close", PullRequestMergeReq{PullRequestID: prID})
+	require.Equal(t, http.StatusOK, code)
+
+	code, _ = sendRequest(t, "POST", "/users/setIsActive", map[string]interface{}{
+		"user_id": released, "is_active": false,
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	code, body = sendRequest(t, "POST", "/pullRequest/reopen", PullRequestMergeReq{PullRequestID: prID})
+	require.Equal(t, http.StatusOK, code)
+	resp = PullRequestResponse{}
+	json.Unmarshal(body, &resp)
+	require.Len(t, resp.PR.AssignedReviewers, 1)
+	current := resp.PR.AssignedReviewers[0]
+	assert.NotEqual(t, released, current)
+
+	code, _ = sendRequest(t, "POST", "/users/setIsActive", map[string]interface{}{
+		"user_id": released, "is_active": true,
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	code, body = sendRequest(t, "POST", "/pullRequest/reassign", PullRequestReassignReq{PullRequestID: prID, OldUserID: current})
+	require.Equal(t, http.StatusOK, code)
+	resp = PullRequestResponse{}
+	json.Unmarshal(body, &resp)
+	assert.Equal(t, released, resp.ReplacedBy)
+}
+
+func TestE2E_ReassignLimit(t *testing.T) {
+	// Тест: после ASSIGNMENT_MAX_REASSIGNMENTS (5 в .env.test) переназначений возвращается REASSIGN_LIMIT
+	teamName := randomString("team_reassign_limit")
+	author := randomString("u_auth")
+	members := []TeamMember{{UserID: author, Username: "Author", IsActive: true}}
+	for i := 0; i < 7; i++ {
+		members = append(members, TeamMember{UserID: randomString("u_rev"), Username: "R", IsActive: true})
+	}
+
+	sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members:  members,
+		Settings: &TeamSettings{MaxReviewers: 1},
+	})
+
+	prID := randomString("pr_reassign_limit")
+	code, body := sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: prID, PullRequestName: "Limit", AuthorID: author,
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	var resp PullRequestResponse
+	json.Unmarshal(body, &resp)
+	require.Len(t, resp.PR.AssignedReviewers, 1)
+	current := resp.PR.AssignedReviewers[0]
+
+	for i := 0; i < 5; i++ {
+		code, body = sendRequest(t, "POST", "/pullRequest/reassign", PullRequestReassignReq{PullRequestID: prID, OldUserID: current})
+		require.Equal(t, http.StatusOK, code)
+
+		resp = PullRequestResponse{}
+		json.Unmarshal(body, &resp)
+		current = resp.ReplacedBy
+	}
+
+	code, body = sendRequest(t, "POST", "/pullRequest/reassign", PullRequestReassignReq{PullRequestID: prID, OldUserID: current})
+	assert.Equal(t, http.StatusConflict, code)
+
+	var errResp ErrorResponse
+	json.Unmarshal(body, &errResp)
+	assert.Equal(t, "REASSIGN_LIMIT", errResp.Error.Code)
+
+	code, _ = sendRequest(t, "POST", "/pullRequest/reassign", PullRequestReassignReq{
+		PullRequestID: prID, OldUserID: current, Actor: "system",
+	})
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// передача ревью при деактивации не ограничена лимитом и не расходует его
+	code, body = sendRequest(t, "POST", "/users/setIsActive", map[string]interface{}{
+		"user_id": current, "is_active": false, "reassign_reviews": true,
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	var handover struct {
+		Reassigned []struct {
+			PullRequestID string `json:"pull_request_id"`
+			ReplacedBy    string `json:"replaced_by"`
+		} `json:"reassigned"`
+	}
+	json.Unmarshal(body, &handover)
+	require.Len(t, handover.Reassigned, 1)
+	assert.Equal(t, prID, handover.Reassigned[0].PullRequestID)
+
+	code, _ = sendRequest(t, "POST", "/pullRequest/reassign", PullRequestReassignReq{
+		PullRequestID: prID, OldUserID: handover.Reassigned[0].ReplacedBy,
+	})
+	assert.Equal(t, http.StatusConflict, code)
+}
+
+func TestE2E_PriorityAndLabels(t *testing.T) {
+	// Тест: CRITICAL PR получает дополнительного ревьювера по правилам команды, приоритет и метки возвращаются
+	teamName := randomString("team_priority")
+	author := randomString("u_auth")
+	reviewers := []string{randomString("u_rev1"), randomString("u_rev2"), randomString("u_rev3"), randomString("u_rev4")}
+
+	members := []TeamMember{{UserID: author, Username: "Author", IsActive: true}}
+	for _, id := range reviewers {
+		members = append(members, TeamMember{UserID: id, Username: "R", IsActive: true})
+	}
+
+	settings := &TeamSettings{MaxReviewers: 2, PriorityRules: &PriorityRules{ExtraReviewersCritical: 1}}
+	code, _ := sendRequest(t, "POST", "/team/add", TeamRequest{TeamName: teamName, Members: members, Settings: settings})
+	require.Equal(t, http.StatusCreated, code)
+
+	prID := randomString("pr_critical")
+	code, body := sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: prID, PullRequestName: "Hotfix", AuthorID: author,
+		Priority: "critical", Labels: []string{"hotfix", " hotfix ", "", "payments"},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	var resp PullRequestResponse
+	json.Unmarshal(body, &resp)
+	assert.Equal(t, "CRITICAL", resp.PR.Priority)
+	assert.Equal(t, []string{"hotfix", "payments"}, resp.PR.Labels)
+	assert.Len(t, resp.PR.AssignedReviewers, 3)
+
+	normalID := randomString("pr_normal")
+	code, body = sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: normalID, PullRequestName: "Refactoring", AuthorID: author,
+	})
+	require.Equal(t, http.StatusCreated, code)
+	resp = PullRequestResponse{}
+	json.Unmarshal(body, &resp)
+	assert.Equal(t, "NORMAL", resp.PR.Priority)
+	assert.Equal(t, []string{}, resp.PR.Labels)
+	assert.Len(t, resp.PR.AssignedReviewers, 2)
+
+	reviewer := resp.PR.AssignedReviewers[0]
+	code, body = sendRequest(t, "GET", "/users/getReview?user_id="+reviewer, nil)
+	require.Equal(t, http.StatusOK, code)
+
+	var reviews UserReviewsResponse
+	json.Unmarshal(body, &reviews)
+	for _, pr := range reviews.PullRequests {
+		if pr.ID == normalID {
+			assert.Equal(t, "NORMAL", pr.Priority)
+		}
+		if pr.ID == prID {
+			assert.Equal(t, "CRITICAL", pr.Priority)
+			assert.Equal(t, []string{"hotfix", "payments"}, pr.Labels)
+		}
+	}
+
+	code, _ = sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: randomString("pr_bad"), PullRequestName: "Bad", AuthorID: author, Priority: "urgent",
+	})
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestE2E_TeamMembership(t *testing.T) {
+	// Сценарий: в команду добавляют нового участника, уходящий участник передаёт ему ревью,
+	// команду переименовывают, а удалить можно только пустую или архивную команду.
+	teamName := randomString("team_members")
+	author := randomString("u_auth")
+	leaving := randomString("u_leaving")
+	newcomer := randomString("u_new")
+
+	sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "A", IsActive: true},
+			{UserID: leaving, Username: "L", IsActive: true},
+		},
+	})
+
+	prID := randomString("pr_members")
+	code, body := sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: prID, PullRequestName: "Members", AuthorID: author,
+	})
+	require.Equal(t, http.StatusCreated, code)
+	var pr PullRequestResponse
+	json.Unmarshal(body, &pr)
+	require.Equal(t, []string{leaving}, pr.PR.AssignedReviewers)
+
+	code, body = sendRequest(t, "POST", "/team/addMember", TeamRequest{
+		TeamName: teamName,
+		Members:  []TeamMember{{UserID: newcomer, Username: "N", IsActive: true}},
+	})
+	require.Equal(t, http.StatusOK, code)
+	var team TeamRequest
+	json.Unmarshal(body, &team)
+	assert.Len(t, team.Members, 3)
+
+	code, body = sendRequest(t, "POST", "/team/removeMember", map[string]interface{}{
+		"team_name": teamName, "user_id": leaving, "reassign_reviews": true,
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	var removed struct {
+		Team       TeamRequest `json:"team"`
+		Reassigned []struct {
+			PullRequestID string `json:"pull_request_id"`
+			ReplacedBy    string `json:"replaced_by"`
+		} `json:"reassigned"`
+	}
+	json.Unmarshal(body, &removed)
+	assert.Len(t, removed.Team.Members, 2)
+	require.Len(t, removed.Reassigned, 1)
+	assert.Equal(t, prID, removed.Reassigned[0].PullRequestID)
+	assert.Equal(t, newcomer, removed.Reassigned[0].ReplacedBy)
+
+	code, _ = sendRequest(t, "POST", "/team/removeMember", map[string]interface{}{
+		"team_name": teamName, "user_id": leaving,
+	})
+	assert.Equal(t, http.StatusNotFound, code)
+
+	newName := randomString("team_renamed")
+	code, body = sendRequest(t, "POST", "/team/rename", map[string]string{
+		"team_name": teamName, "new_team_name": newName,
+	})
+	require.Equal(t, http.StatusOK, code)
+	team = TeamRequest{}
+	json.Unmarshal(body, &team)
+	assert.Equal(t, newName, team.TeamName)
+
+	code, _ = sendRequest(t, "GET", fmt.Sprintf("/team/get?team_name=%s", teamName), nil)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	archived := randomString("team_archived")
+	oldMember := randomString("u_old")
+	sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: archived,
+		Members:  []TeamMember{{UserID: oldMember, Username: "O", IsActive: false}},
+	})
+
+	code, body = sendRequest(t, "POST", "/team/rename", map[string]string{
+		"team_name": archived, "new_team_name": newName,
+	})
+	assert.Equal(t, http.StatusBadRequest, code)
+	var errResp ErrorResponse
+	json.Unmarshal(body, &errResp)
+	assert.Equal(t, "TEAM_EXISTS", errResp.Error.Code)
+
+	code, body = sendRequest(t, "POST", "/team/delete", map[string]string{"team_name": newName})
+	assert.Equal(t, http.StatusConflict, code)
+	errResp = ErrorResponse{}
+	json.Unmarshal(body, &errResp)
+	assert.Equal(t, "TEAM_NOT_EMPTY", errResp.Error.Code)
+
+	code, body = sendRequest(t, "POST", "/team/delete", map[string]string{"team_name": archived})
+	assert.Equal(t, http.StatusConflict, code)
+	errResp = ErrorResponse{}
+	json.Unmarshal(body, &errResp)
+	assert.Equal(t, "TEAM_NOT_EMPTY", errResp.Error.Code)
+
+	code, body = sendRequest(t, "POST", "/team/setArchived", map[string]interface{}{
+		"team_name": archived, "archived": true,
+	})
+	require.Equal(t, http.StatusOK, code)
+	var archivedTeam struct {
+		Archived bool `json:"archived"`
+	}
+	json.Unmarshal(body, &archivedTeam)
+	assert.True(t, archivedTeam.Archived)
+
+	code, _ = sendRequest(t, "POST", "/team/delete", map[string]string{"team_name": archived})
+	require.Equal(t, http.StatusOK, code)
+
+	code, _ = sendRequest(t, "GET", "/users/get?user_id="+oldMember, nil)
+	assert.Equal(t, http.StatusOK, code)
+
+	code, _ = sendRequest(t, "GET", fmt.Sprintf("/team/get?team_name=%s", archived), nil)
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestE2E_MultiTeamUser(t *testing.T) {
+	// Сценарий: автор состоит в продуктовой команде и в гильдии,
+	// ревьюверы PR берутся из выбранной команды, остальные членства при добавлении сохраняются.
+	product := randomString("team_product")
+	guild := randomString("team_guild")
+	author := randomString("u_auth")
+	productRev := randomString("u_prod")
+	guildRev := randomString("u_guild")
+
+	sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: product,
+		Members: []TeamMember{
+			{UserID: author, Username: "A", IsActive: true},
+			{UserID: productRev, Username: "P", IsActive: true},
+		},
+	})
+	sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: guild,
+		Members:  []TeamMember{{UserID: guildRev, Username: "G", IsActive: true}},
+	})
+
+	code, _ := sendRequest(t, "POST", "/team/addMember", TeamRequest{
+		TeamName: guild,
+		Members:  []TeamMember{{UserID: author, Username: "A", IsActive: true}},
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	code, body := sendRequest(t, "GET", fmt.Sprintf("/team/get?team_name=%s", product), nil)
+	require.Equal(t, http.StatusOK, code)
+	var team TeamRequest
+	json.Unmarshal(body, &team)
+	assert.Len(t, team.Members, 2)
+
+	prID := randomString("pr_product")
+	code, body = sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: prID, PullRequestName: "Product", AuthorID: author,
+	})
+	require.Equal(t, http.StatusCreated, code)
+	var resp PullRequestResponse
+	json.Unmarshal(body, &resp)
+	assert.Equal(t, product, resp.PR.TeamName)
+	assert.Equal(t, []string{productRev}, resp.PR.AssignedReviewers)
+
+	guildPR := randomString("pr_guild")
+	code, body = sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: guildPR, PullRequestName: "Guild", AuthorID: author, TeamName: guild,
+	})
+	require.Equal(t, http.StatusCreated, code)
+	resp = PullRequestResponse{}
+	json.Unmarshal(body, &resp)
+	assert.Equal(t, guild, resp.PR.TeamName)
+	assert.Equal(t, []string{guildRev}, resp.PR.AssignedReviewers)
+
+	code, _ = sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: randomString("pr_foreign"), PullRequestName: "Foreign", AuthorID: productRev, TeamName: guild,
+	})
+	assert.Equal(t, http.StatusNotFound, code)
+
+	code, body = sendRequest(t, "POST", "/users/setPrimaryTeam", map[string]string{
+		"user_id": author, "team_name": guild,
+	})
+	require.Equal(t, http.StatusOK, code)
+	var userResp struct {
+		User struct {
+			TeamName string   `json:"team_name"`
+			Teams    []string `json:"teams"`
+		} `json:"user"`
+	}
+	json.Unmarshal(body, &userResp)
+	assert.Equal(t, guild, userResp.User.TeamName)
+	assert.ElementsMatch(t, []string{product, guild}, userResp.User.Teams)
+
+	code, _ = sendRequest(t, "POST", "/users/setPrimaryTeam", map[string]string{
+		"user_id": productRev, "team_name": guild,
+	})
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestE2E_UsersDirectory(t *testing.T) {
+	// Сценарий: пользователя можно прочитать с нагрузкой, список фильтруется и листается курсором.
+	teamName := randomString("team_dir")
+	prefix := randomString("Dir")
+	author := randomString("u_auth")
+	reviewer := randomString("u_rev")
+	archived := randomString("u_old")
+
+	sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: prefix + "-alice", IsActive: true},
+			{UserID: reviewer, Username: prefix + "-bob", IsActive: true},
+			{UserID: archived, Username: prefix + "-carol", IsActive: false},
+		},
+	})
+
+	code, _ := sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: randomString("pr_dir"), PullRequestName: "Directory", AuthorID: author,
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	code, body := sendRequest(t, "GET", "/users/get?user_id="+reviewer, nil)
+	require.Equal(t, http.StatusOK, code)
+	var details struct {
+		User struct {
+			UserID   string   `json:"user_id"`
+			TeamName string   `json:"team_name"`
+			Teams    []string `json:"teams"`
+			IsActive bool     `json:"is_active"`
+		} `json:"user"`
+		OpenReviews int `json:"open_reviews"`
+	}
+	json.Unmarshal(body, &details)
+	assert.Equal(t, teamName, details.User.TeamName)
+	assert.Equal(t, []string{teamName}, details.User.Teams)
+	assert.True(t, details.User.IsActive)
+	assert.Equal(t, 1, details.OpenReviews)
+
+	code, _ = sendRequest(t, "GET", "/users/get?user_id="+randomString("u_missing"), nil)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	type userList struct {
+		Users []struct {
+			UserID string `json:"user_id"`
+		} `json:"users"`
+		NextCursor string `json:"next_cursor"`
+	}
+
+	code, body = sendRequest(t, "GET", "/users/list?limit=2&username="+strings.ToLower(prefix), nil)
+	require.Equal(t, http.StatusOK, code)
+	var page userList
+	json.Unmarshal(body, &page)
+	require.Len(t, page.Users, 2)
+	assert.Equal(t, author, page.Users[0].UserID)
+	assert.Equal(t, reviewer, page.Users[1].UserID)
+	require.NotEmpty(t, page.NextCursor)
+
+	code, body = sendRequest(t, "GET", "/users/list?limit=2&username="+strings.ToLower(prefix)+"&cursor="+page.NextCursor, nil)
+	require.Equal(t, http.StatusOK, code)
+	page = userList{}
+	json.Unmarshal(body, &page)
+	require.Len(t, page.Users, 1)
+	assert.Equal(t, archived, page.Users[0].UserID)
+	assert.Empty(t, page.NextCursor)
+
+	code, body = sendRequest(t, "GET", "/users/list?is_active=false&team_name="+teamName, nil)
+	require.Equal(t, http.StatusOK, code)
+	page = userList{}
+	json.Unmarshal(body, &page)
+	require.Len(t, page.Users, 1)
+	assert.Equal(t, archived, page.Users[0].UserID)
+
+	code, _ = sendRequest(t, "GET", "/users/list?is_active=maybe", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestE2E_TeamHierarchy(t *testing.T) {
+	// Сценарий: департамент -> трайб -> два сквада, дерево и цепочка предков читаются,
+	// а перенос, образующий цикл, отклоняется.
+	dept := randomString("dept")
+	tribe := randomString("tribe")
+	squadA := randomString("squad_a")
+	squadB := randomString("squad_b")
+
+	code, _ := sendRequest(t, "POST", "/team/add", TeamRequest{TeamName: dept, Members: []TeamMember{}})
+	require.Equal(t, http.StatusCreated, code)
+	code, _ = sendRequest(t, "POST", "/team/add", TeamRequest{TeamName: tribe, ParentTeam: dept, Members: []TeamMember{}})
+	require.Equal(t, http.StatusCreated, code)
+	code, _ = sendRequest(t, "POST", "/team/add", TeamRequest{TeamName: squadA, ParentTeam: tribe, Members: []TeamMember{}})
+	require.Equal(t, http.StatusCreated, code)
+	code, _ = sendRequest(t, "POST", "/team/add", TeamRequest{TeamName: squadB, Members: []TeamMember{}})
+	require.Equal(t, http.StatusCreated, code)
+
+	code, body := sendRequest(t, "POST", "/team/setParent", map[string]string{
+		"team_name": squadB, "parent_team": tribe,
+	})
+	require.Equal(t, http.StatusOK, code)
+	var team TeamRequest
+	json.Unmarshal(body, &team)
+	assert.Equal(t, tribe, team.ParentTeam)
+
+	type teamTree struct {
+		TeamName string `json:"team_name"`
+		Children []struct {
+			TeamName string `json:"team_name"`
+			Children []struct {
+				TeamName string `json:"team_name"`
+			} `json:"children"`
+		} `json:"children"`
+	}
+	code, body = sendRequest(t, "GET", "/team/subtree?team_name="+dept, nil)
+	require.Equal(t, http.StatusOK, code)
+	var tree teamTree
+	json.Unmarshal(body, &tree)
+	require.Len(t, tree.Children, 1)
+	assert.Equal(t, tribe, tree.Children[0].TeamName)
+	require.Len(t, tree.Children[0].Children, 2)
+	assert.Equal(t, squadA, tree.Children[0].Children[0].TeamName)
+	assert.Equal(t, squadB, tree.Children[0].Children[1].TeamName)
+
+	code, body = sendRequest(t, "GET", "/team/ancestors?team_name="+squadA, nil)
+	require.Equal(t, http.StatusOK, code)
+	var ancestors struct {
+		Ancestors []string `json:"ancestors"`
+	}
+	json.Unmarshal(body, &ancestors)
+	assert.Equal(t, []string{tribe, dept}, ancestors.Ancestors)
+
+	code, _ = sendRequest(t, "POST", "/team/setParent", map[string]string{
+		"team_name": dept, "parent_team": squadA,
+	})
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = sendRequest(t, "POST", "/team/setParent", map[string]string{
+		"team_name": tribe, "parent_team": tribe,
+	})
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = sendRequest(t, "GET", "/team/ancestors?team_name="+randomString("missing"), nil)
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestE2E_MaintainerRequired(t *testing.T) {
+	// Сценарий: команда требует мейнтейнера среди ревьюверов. Единственный ревьювер — мейнтейнер,
+	// при переназначении его заменяет лид, а когда мейнтейнеров не осталось, ответ сообщает об этом.
+	teamName := randomString("team_maint")
+	author := randomString("u_auth")
+	maint := randomString("u_maint")
+	m1 := randomString("u_m1")
+	m2 := randomString("u_m2")
+	code, _ := sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "A", IsActive: true},
+			{UserID: maint, Username: "M", IsActive: true, Role: "maintainer"},
+			{UserID: m1, Username: "M1", IsActive: true},
+			{UserID: m2, Username: "M2", IsActive: true},
+		},
+		Settings: &TeamSettings{MaxReviewers: 1, RequireMaintainer: true},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	prID := randomString("pr_maint")
+	code, body := sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: prID, PullRequestName: "Maintainer", AuthorID: author,
+	})
+	require.Equal(t, http.StatusCreated, code)
+	var resp PullRequestResponse
+	json.Unmarshal(body, &resp)
+	assert.Equal(t, []string{maint}, resp.PR.AssignedReviewers)
+	assert.False(t, resp.MaintainerMissing)
+
+	lead := randomString("u_lead")
+	code, body = sendRequest(t, "POST", "/team/addMember", TeamRequest{
+		TeamName: teamName,
+		Members:  []TeamMember{{UserID: lead, Username: "L", IsActive: true, Role: "LEAD"}},
+	})
+	require.Equal(t, http.StatusOK, code)
+	var team TeamRequest
+	json.Unmarshal(body, &team)
+	roles := map[string]string{}
+	for _, m := range team.Members {
+		roles[m.UserID] = m.Role
+	}
+	assert.Equal(t, "MAINTAINER", roles[maint])
+	assert.Equal(t, "LEAD", roles[lead])
+	assert.Equal(t, "MEMBER", roles[m1])
+
+	code, body = sendRequest(t, "POST", "/pullRequest/reassign", PullRequestReassignReq{PullRequestID: prID, OldUserID: maint})
+	require.Equal(t, http.StatusOK, code)
+	resp = PullRequestResponse{}
+	json.Unmarshal(body, &resp)
+	assert.Equal(t, lead, resp.ReplacedBy)
+	assert.False(t, resp.MaintainerMissing)
+
+	// бывший ревьювер maint больше не выбирается, других мейнтейнеров нет
+	code, body = sendRequest(t, "POST", "/pullRequest/reassign", PullRequestReassignReq{PullRequestID: prID, OldUserID: lead})
+	require.Equal(t, http.StatusOK, code)
+	resp = PullRequestResponse{}
+	json.Unmarshal(body, &resp)
+	assert.Contains(t, []string{m1, m2}, resp.ReplacedBy)
+	assert.True(t, resp.MaintainerMissing)
+
+	code, _ = sendRequest(t, "POST", "/team/addMember", TeamRequest{
+		TeamName: teamName,
+		Members:  []TeamMember{{UserID: m1, Username: "M1", IsActive: true, Role: "OWNER"}},
+	})
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestE2E_MaintainerWithCodeOwners(t *testing.T) {
+	// Сценарий: единственный слот достаётся мейнтейнеру, а не владельцу кода без роли;
+	// из мейнтейнеров предпочтителен владелец кода, при переназначении его сменяет другой мейнтейнер.
+	teamName := randomString("team_maint_co")
+	author := randomString("u_auth")
+	owner := randomString("u_owner")
+	maint := randomString("u_maint")
+	maintOwner := randomString("u_maint_owner")
+	code, _ := sendRequest(t, "POST", "/team/add", TeamRequest{
+		TeamName: teamName,
+		Members: []TeamMember{
+			{UserID: author, Username: "A", IsActive: true},
+			{UserID: owner, Username: "O", IsActive: true},
+			{UserID: maint, Username: "M", IsActive: true, Role: "MAINTAINER"},
+		},
+		Settings: &TeamSettings{MaxReviewers: 1, RequireMaintainer: true},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	dir := randomString("dir")
+	code, _ = sendRequest(t, "POST", "/codeOwners/import", map[string]string{
+		"content": fmt.Sprintf("/%s/ @%s @%s\n", dir, owner, maintOwner),
+	})
+	require.Equal(t, http.StatusOK, code)
+	defer sendRequest(t, "POST", "/codeOwners/set", map[string]interface{}{"rules": []interface{}{}})
+
+	code, body := sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: randomString("pr_maint_co"), PullRequestName: "Owned", AuthorID: author,
+		ChangedPaths: []string{dir + "/main.go"},
+	})
+	require.Equal(t, http.StatusCreated, code)
+	var resp PullRequestResponse
+	json.Unmarshal(body, &resp)
+	assert.Equal(t, []string{maint}, resp.PR.AssignedReviewers)
+	assert.False(t, resp.MaintainerMissing)
+
+	code, _ = sendRequest(t, "POST", "/team/addMember", TeamRequest{
+		TeamName: teamName,
+		Members:  []TeamMember{{UserID: maintOwner, Username: "MO", IsActive: true, Role: "MAINTAINER"}},
+	})
+	require.Equal(t, http.StatusOK, code)
+
+	prID := randomString("pr_maint_co")
+	code, body = sendRequest(t, "POST", "/pullRequest/create", PullRequestCreateReq{
+		PullRequestID: prID, PullRequestName: "Owned", AuthorID: author,
+		ChangedPaths: []string{dir + "/main.go"},
+	})
+	require.Equal(t, http.StatusCreated, code)
+	resp = PullRequestResponse{}
+	json.Unmarshal(body, &resp)
+	assert.Equal(t, []string{maintOwner}, resp.PR.AssignedReviewers)
+
+	code, body = sendRequest(t, "POST", "/pullRequest/reassign", PullRequestReassignReq{PullRequestID: prID, OldUserID: maintOwner})
+	require.Equal(t, http.StatusOK, code)
+	resp = PullRequestResponse{}
+	json.Unmarshal(body, &resp)
+	assert.Equal(t, maint, resp.ReplacedBy)
+	assert.False(t, resp.MaintainerMissing)
+}